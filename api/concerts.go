@@ -0,0 +1,80 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"groupie-tracker/models"
+)
+
+// FetchAllRelations récupère les concerts de tous les artistes en une seule requête
+func FetchAllRelations() (map[int]map[string][]string, error) {
+	resp, err := http.Get(baseURL + "/relation")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("erreur API: status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Index []models.Relation `json:"index"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	relations := make(map[int]map[string][]string)
+	for _, r := range result.Index {
+		relations[r.ID] = r.DatesLocations
+	}
+	return relations, nil
+}
+
+// Concerts transforme une relation (lieu -> dates "jj-mm-aaaa") en concerts triés par date.
+// Les dates illisibles sont ignorées.
+func Concerts(artistID int, datesLocations map[string][]string) []models.Concert {
+	var concerts []models.Concert
+	for location, dates := range datesLocations {
+		for _, d := range dates {
+			date, err := time.Parse("02-01-2006", strings.TrimPrefix(strings.TrimSpace(d), "*"))
+			if err != nil {
+				continue
+			}
+			concerts = append(concerts, models.Concert{ArtistID: artistID, Location: location, Date: date})
+		}
+	}
+	sort.Slice(concerts, func(i, j int) bool {
+		if !concerts[i].Date.Equal(concerts[j].Date) {
+			return concerts[i].Date.Before(concerts[j].Date)
+		}
+		return concerts[i].Location < concerts[j].Location
+	})
+	return concerts
+}
+
+// LocationLabel rend un lieu de l'API lisible : "north_carolina-usa" -> "North Carolina, USA"
+func LocationLabel(loc string) string {
+	parts := strings.Split(loc, "-")
+	for i, part := range parts {
+		words := strings.Fields(strings.ReplaceAll(part, "_", " "))
+		for j, w := range words {
+			if i == len(parts)-1 && i > 0 && len(w) <= 3 {
+				words[j] = strings.ToUpper(w) // codes pays : usa, uk...
+				continue
+			}
+			runes := []rune(w)
+			runes[0] = unicode.ToUpper(runes[0])
+			words[j] = string(runes)
+		}
+		parts[i] = strings.Join(words, " ")
+	}
+	return strings.Join(parts, ", ")
+}
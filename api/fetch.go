@@ -5,13 +5,19 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"sync"
 
 	"groupie-tracker/models"
 )
 
 const baseURL = "https://groupietrackers.herokuapp.com/api"
 
-var cacheRelation = make(map[int]*models.Relation)
+// Relations déjà téléchargées, par ID d'artiste. Le serveur web les demande
+// depuis plusieurs requêtes à la fois : l'accès passe par relationLock.
+var (
+	relationLock  sync.RWMutex
+	cacheRelation = make(map[int]*models.Relation)
+)
 
 func FetchArtists() ([]models.Artist, error) {
 	resp, err := http.Get(baseURL + "/artists")
@@ -34,24 +40,29 @@ func FetchArtists() ([]models.Artist, error) {
 }
 
 func FetchRelation(id int) (*models.Relation, error) {
-    if donnee, existe := cacheRelation[id]; existe {
-        return donnee, nil
-    }
-
-    resp, err := http.Get(baseURL + "/relation/" + strconv.Itoa(id))
-    if err != nil {
-        return nil, err
-    }
-    defer resp.Body.Close()
-
-    var relation models.Relation
-    err = json.NewDecoder(resp.Body).Decode(&relation)
-    if err != nil {
-        return nil, err
-    }
-
-    cacheRelation[id] = &relation
-    return &relation, nil
+	relationLock.RLock()
+	donnee, existe := cacheRelation[id]
+	relationLock.RUnlock()
+	if existe {
+		return donnee, nil
+	}
+
+	resp, err := http.Get(baseURL + "/relation/" + strconv.Itoa(id))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var relation models.Relation
+	err = json.NewDecoder(resp.Body).Decode(&relation)
+	if err != nil {
+		return nil, err
+	}
+
+	relationLock.Lock()
+	cacheRelation[id] = &relation
+	relationLock.Unlock()
+	return &relation, nil
 }
 
 func FetchLocations(id int) (*models.Location, error) {
@@ -77,6 +88,9 @@ func FetchAllLocationsMap() (map[int][]string, error) {
 		return nil, err
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("erreur API: status %d", resp.StatusCode)
+	}
 
 	var result struct {
 		Index []struct {
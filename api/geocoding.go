@@ -3,20 +3,116 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"math"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
+
+	"groupie-tracker/models"
 )
 
-type GeoResult struct {
-	Lat string `json:"lat"`
-	Lon string `json:"lon"`
-}
+type GeoResult = models.Coordinates
 
 var client = &http.Client{Timeout: 10 * time.Second}
 
+// GeocodeStore conserve les lieux géocodés d'un lancement à l'autre, par
+// lieu lisible en minuscules
+type GeocodeStore interface {
+	Load() (map[string]GeoResult, error)
+	Save(place string, res GeoResult) error
+}
+
+// Nominatim demande au plus une requête par seconde : les résultats sont
+// gardés en mémoire (et dans geoStore s'il est défini), et les requêtes espacées
+var (
+	geoLock    sync.Mutex
+	geoCache   = make(map[string]GeoResult)
+	geoStore   GeocodeStore
+	geoLoaded  bool
+	geoLast    time.Time
+	geoSpacing = time.Second
+)
+
+// SetGeocodeStore définit où les lieux géocodés sont conservés ; sans appel,
+// ils ne sont gardés qu'en mémoire. Les lieux déjà enregistrés sont relus au
+// premier besoin.
+func SetGeocodeStore(s GeocodeStore) {
+	geoLock.Lock()
+	defer geoLock.Unlock()
+	geoStore, geoLoaded = s, false
+}
+
+// ReloadGeocodes relit les lieux enregistrés, y compris ceux géocodés depuis
+// par un autre mode de l'application (le proxy l'appelle à chaque
+// rechargement de ses données)
+func ReloadGeocodes() error {
+	geoLock.Lock()
+	s := geoStore
+	geoLoaded = true
+	geoLock.Unlock()
+	if s == nil {
+		return nil
+	}
+
+	saved, err := s.Load()
+	if err != nil {
+		return err
+	}
+	geoLock.Lock()
+	defer geoLock.Unlock()
+	maps.Copy(geoCache, saved)
+	return nil
+}
+
+// loadGeoCache reprend, au premier besoin, les lieux géocodés lors des
+// lancements précédents
+func loadGeoCache() {
+	geoLock.Lock()
+	loaded := geoLoaded
+	geoLock.Unlock()
+	if !loaded {
+		ReloadGeocodes()
+	}
+}
+
+// CachedCoordinates retourne les coordonnées d'un lieu déjà géocodé, sans requête réseau
+func CachedCoordinates(city string) (string, string, bool) {
+	loadGeoCache()
+	geoLock.Lock()
+	defer geoLock.Unlock()
+	res, ok := geoCache[strings.ToLower(city)]
+	return res.Lat, res.Lon, ok
+}
+
 func GetCoordinates(city string) (string, string, error) {
+	if lat, lon, ok := CachedCoordinates(city); ok {
+		return lat, lon, nil
+	}
+	lat, lon, err := fetchCoordinates(city)
+	if err == nil {
+		key, res := strings.ToLower(city), GeoResult{Lat: lat, Lon: lon}
+		geoLock.Lock()
+		geoCache[key] = res
+		s := geoStore
+		geoLock.Unlock()
+		// Si l'écriture échoue, le lieu sera simplement géocodé à nouveau
+		if s != nil {
+			s.Save(key, res)
+		}
+	}
+	return lat, lon, err
+}
+
+func fetchCoordinates(city string) (string, string, error) {
+	geoLock.Lock()
+	wait := time.Until(geoLast.Add(geoSpacing))
+	geoLast = time.Now().Add(max(wait, 0))
+	geoLock.Unlock()
+	time.Sleep(wait)
+
 	q := url.QueryEscape(city)
 	url := fmt.Sprintf("https://nominatim.openstreetmap.org/search?q=%s&format=json&limit=1", q)
 
@@ -40,5 +136,10 @@ func GetOSMTileURL(lat, lon float64, zoom int) string {
 	x := int(math.Floor((lon + 180.0) / 360.0 * math.Pow(2.0, float64(zoom))))
 	latRad := lat * math.Pi / 180.0
 	y := int(math.Floor((1.0 - math.Log(math.Tan(latRad)+1.0/math.Cos(latRad))/math.Pi) / 2.0 * math.Pow(2.0, float64(zoom))))
+	return OSMTileURL(zoom, x, y)
+}
+
+// OSMTileURL retourne l'adresse d'une tuile OpenStreetMap
+func OSMTileURL(zoom, x, y int) string {
 	return fmt.Sprintf("https://tile.openstreetmap.org/%d/%d/%d.png", zoom, x, y)
 }
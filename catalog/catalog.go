@@ -0,0 +1,149 @@
+// Package catalog rassemble les données affichées par les modes sans fenêtre
+// (ligne de commande, serveurs) : artistes de l'API, groupes créés par
+// l'utilisateur, lieux et concerts.
+package catalog
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"groupie-tracker/api"
+	"groupie-tracker/filter"
+	"groupie-tracker/models"
+	"groupie-tracker/store"
+)
+
+// Catalog contient les artistes connus et leurs lieux de concert
+type Catalog struct {
+	Artists   []models.Artist  // triés par nom
+	Locations map[int][]string // ID d'artiste -> lieux de concert
+
+	bands map[int]models.LocalBand
+
+	mu        sync.Mutex
+	index     *filter.Index
+	relations map[int]map[string][]string
+}
+
+// Load charge les artistes de l'API et les groupes locaux.
+// withLocations récupère aussi les lieux de concert (nécessaires au filtre par lieu).
+func Load(withLocations bool) (*Catalog, error) {
+	artists, err := api.FetchArtists()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Catalog{
+		Artists:   artists,
+		Locations: make(map[int][]string),
+		bands:     make(map[int]models.LocalBand),
+	}
+	if withLocations {
+		// Sans les lieux, le filtre par lieu ne trouverait aucun artiste
+		if c.Locations, err = api.FetchAllLocationsMap(); err != nil {
+			return nil, err
+		}
+	}
+
+	bands, _ := store.LoadLocalBands()
+	for _, b := range bands {
+		c.Artists = append(c.Artists, b.Artist)
+		c.bands[b.Artist.ID] = b
+		locs := make([]string, 0, len(b.DatesLocations))
+		for city := range b.DatesLocations {
+			locs = append(locs, city)
+		}
+		sort.Strings(locs)
+		c.Locations[b.Artist.ID] = locs
+	}
+
+	sort.Slice(c.Artists, func(i, j int) bool {
+		return strings.ToLower(c.Artists[i].Name) < strings.ToLower(c.Artists[j].Name)
+	})
+	return c, nil
+}
+
+// Index retourne l'index de recherche (construit au premier appel)
+func (c *Catalog) Index() *filter.Index {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.index == nil {
+		c.index = filter.NewIndex(c.Artists, c.Locations)
+	}
+	return c.index
+}
+
+// Find cherche un artiste par ID ou par nom (sans tenir compte de la casse)
+func (c *Catalog) Find(ref string) (models.Artist, bool) {
+	ref = strings.TrimSpace(ref)
+	if id, err := strconv.Atoi(ref); err == nil {
+		for _, a := range c.Artists {
+			if a.ID == id {
+				return a, true
+			}
+		}
+		return models.Artist{}, false
+	}
+	for _, a := range c.Artists {
+		if strings.EqualFold(a.Name, ref) {
+			return a, true
+		}
+	}
+	return models.Artist{}, false
+}
+
+// IsLocal indique si l'artiste a été créé par l'utilisateur
+func (c *Catalog) IsLocal(id int) bool {
+	_, ok := c.bands[id]
+	return ok
+}
+
+// Concerts retourne les concerts d'un artiste, triés par date
+func (c *Catalog) Concerts(id int) ([]models.Concert, error) {
+	if b, ok := c.bands[id]; ok {
+		return api.Concerts(id, b.DatesLocations), nil
+	}
+
+	c.mu.Lock()
+	rel, ok := c.relations[id]
+	c.mu.Unlock()
+	if ok {
+		return api.Concerts(id, rel), nil
+	}
+
+	relation, err := api.FetchRelation(id)
+	if err != nil {
+		return nil, err
+	}
+	return api.Concerts(id, relation.DatesLocations), nil
+}
+
+// AllConcerts retourne les concerts de tous les artistes en une seule requête à l'API
+func (c *Catalog) AllConcerts() (map[int][]models.Concert, error) {
+	c.mu.Lock()
+	relations := c.relations
+	c.mu.Unlock()
+
+	if relations == nil {
+		var err error
+		relations, err = api.FetchAllRelations()
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.relations = relations
+		c.mu.Unlock()
+	}
+
+	all := make(map[int][]models.Concert, len(c.Artists))
+	for _, a := range c.Artists {
+		if b, ok := c.bands[a.ID]; ok {
+			all[a.ID] = api.Concerts(a.ID, b.DatesLocations)
+		} else {
+			all[a.ID] = api.Concerts(a.ID, relations[a.ID])
+		}
+	}
+	return all, nil
+}
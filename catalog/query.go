@@ -0,0 +1,101 @@
+package catalog
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"groupie-tracker/filter"
+	"groupie-tracker/store"
+)
+
+// ParseQuery lit les filtres d'une URL ; le serveur web et le proxy
+// acceptent les mêmes paramètres :
+//
+//	q, min_creation, max_creation, min_album, max_album, members (répété ou 2,3),
+//	location, favorites, tag, min_rating, collection, sort
+func ParseQuery(q url.Values) (filter.Criteria, error) {
+	c := filter.Criteria{
+		Search:        strings.TrimSpace(q.Get("q")),
+		Location:      strings.TrimSpace(q.Get("location")),
+		Tag:           strings.TrimSpace(q.Get("tag")),
+		FavoritesOnly: q.Get("favorites") != "" && q.Get("favorites") != "0" && q.Get("favorites") != "false",
+		Sort:          filter.SortNameAsc,
+	}
+
+	ints := []struct {
+		name string
+		dest *int
+	}{
+		{"min_creation", &c.MinCreation},
+		{"max_creation", &c.MaxCreation},
+		{"min_album", &c.MinAlbum},
+		{"max_album", &c.MaxAlbum},
+		{"min_rating", &c.MinRating},
+	}
+	for _, p := range ints {
+		v := strings.TrimSpace(q.Get(p.name))
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return c, fmt.Errorf("paramètre %s invalide : %q", p.name, v)
+		}
+		*p.dest = n
+	}
+
+	for _, v := range q["members"] {
+		for _, part := range strings.Split(v, ",") {
+			part = strings.TrimSuffix(strings.TrimSpace(part), "+")
+			if part == "" {
+				continue
+			}
+			n, err := strconv.Atoi(part)
+			if err != nil || n < 1 {
+				return c, fmt.Errorf("paramètre members invalide : %q", part)
+			}
+			c.Members = append(c.Members, min(n, filter.MembersMax))
+		}
+	}
+
+	if v := q.Get("sort"); v != "" {
+		c.Sort = ""
+		for _, k := range filter.SortKeys {
+			if string(k) == v {
+				c.Sort = k
+			}
+		}
+		if c.Sort == "" {
+			return c, fmt.Errorf("paramètre sort invalide : %q", v)
+		}
+	}
+
+	if name := strings.TrimSpace(q.Get("collection")); name != "" {
+		ids, err := CollectionIDs(name)
+		if err != nil {
+			return c, err
+		}
+		c.ArtistIDs = ids
+	}
+	return c, nil
+}
+
+// CollectionIDs retourne les artistes de la collection name (sans tenir compte de la casse)
+func CollectionIDs(name string) (map[int]bool, error) {
+	cols, err := store.LoadCollections()
+	if err != nil {
+		return nil, err
+	}
+	for _, col := range cols {
+		if strings.EqualFold(col.Name, name) {
+			ids := make(map[int]bool, len(col.ArtistIDs))
+			for _, id := range col.ArtistIDs {
+				ids[id] = true
+			}
+			return ids, nil
+		}
+	}
+	return nil, fmt.Errorf("collection inconnue : %q", name)
+}
@@ -0,0 +1,55 @@
+package catalog
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"groupie-tracker/filter"
+	"groupie-tracker/models"
+	"groupie-tracker/store"
+)
+
+func TestParseQuery(t *testing.T) {
+	t.Setenv("GROUPIE_TRACKER_HOME", t.TempDir())
+	if err := store.SaveCollections([]models.Collection{{ID: 1, Name: "Été 2026", ArtistIDs: []int{3, 7}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query   string
+		want    filter.Criteria
+		wantErr bool
+	}{
+		{"", filter.Criteria{Sort: filter.SortNameAsc}, false},
+		{"q=+queen+&location=paris&tag=live", filter.Criteria{Search: "queen", Location: "paris", Tag: "live", Sort: filter.SortNameAsc}, false},
+		{"min_creation=1970&max_creation=1980&min_album=1975&max_album=1990&min_rating=4",
+			filter.Criteria{MinCreation: 1970, MaxCreation: 1980, MinAlbum: 1975, MaxAlbum: 1990, MinRating: 4, Sort: filter.SortNameAsc}, false},
+		{"members=2,3&members=12+", filter.Criteria{Members: []int{2, 3, filter.MembersMax}, Sort: filter.SortNameAsc}, false},
+		{"favorites=1", filter.Criteria{FavoritesOnly: true, Sort: filter.SortNameAsc}, false},
+		{"favorites=false", filter.Criteria{Sort: filter.SortNameAsc}, false},
+		{"favorites=0", filter.Criteria{Sort: filter.SortNameAsc}, false},
+		{"sort=creation-desc", filter.Criteria{Sort: filter.SortCreationNew}, false},
+		{"collection=%C3%A9T%C3%A9+2026", filter.Criteria{ArtistIDs: map[int]bool{3: true, 7: true}, Sort: filter.SortNameAsc}, false},
+		{"min_creation=abc", filter.Criteria{}, true},
+		{"members=0", filter.Criteria{}, true},
+		{"members=deux", filter.Criteria{}, true},
+		{"sort=aleatoire", filter.Criteria{}, true},
+		{"collection=inconnue", filter.Criteria{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ParseQuery(q)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("erreur = %v", err)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQuery = %+v\nattendu %+v", got, tt.want)
+			}
+		})
+	}
+}
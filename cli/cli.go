@@ -0,0 +1,457 @@
+// Package cli implémente le mode ligne de commande :
+//
+//	groupie-tracker list|search|show|concerts|export|tui|web [options]
+//
+// Il utilise les mêmes données (API, favoris, groupes locaux) et le même
+// filtrage que l'interface graphique, sans avoir besoin d'écran.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"groupie-tracker/catalog"
+	"groupie-tracker/filter"
+	"groupie-tracker/models"
+	"groupie-tracker/proxy"
+	"groupie-tracker/store"
+	"groupie-tracker/tui"
+	"groupie-tracker/web"
+)
+
+// Codes de sortie
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+type command struct {
+	name string
+	args string
+	help string
+	run  func(env *env, args []string) error
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"list", "[filtres]", "liste les artistes", runList},
+		{"search", "<texte> [filtres]", "cherche un artiste ou un membre", runSearch},
+		{"show", "<id|nom>", "affiche la fiche d'un artiste", runShow},
+		{"concerts", "<id|nom> [-format table|json|csv|ics|geojson|kml] [-tour]", "liste les concerts d'un artiste", runConcerts},
+		{"export", "[filtres] [-format json|csv|ics|geojson|kml] [-tour] [-o fichier]", "exporte les artistes, leurs concerts et favoris", runExport},
+		{"tui", "", "interface en mode terminal", runTUI},
+		{"web", "[-addr hôte:port]", "serveur web local (pages HTML et API JSON)", runWeb},
+		{"proxy", "[-addr hôte:port] [-ttl durée]", "proxy REST d'agrégation (/v1/artists)", runProxy},
+		{"help", "", "affiche cette aide", runHelp},
+	}
+}
+
+// errUsage signale des arguments invalides (l'aide de la commande est affichée)
+var errUsage = errors.New("usage")
+
+// flagError est une option invalide : son message précède l'aide de la commande
+type flagError struct{ err error }
+
+func (f flagError) Error() string        { return f.err.Error() }
+func (f flagError) Is(target error) bool { return target == errUsage }
+
+type env struct {
+	stdout io.Writer
+	stderr io.Writer
+	flags  *flag.FlagSet // options de la commande en cours, listées par -h
+}
+
+// IsCommand indique si name est une commande du mode ligne de commande
+func IsCommand(name string) bool {
+	if name == "-h" || name == "--help" {
+		return true
+	}
+	for _, c := range commands {
+		if c.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Run exécute la commande args[0] et retourne le code de sortie du programme
+func Run(args []string, stdout, stderr io.Writer) int {
+	e := &env{stdout: stdout, stderr: stderr}
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+		runHelp(e, nil)
+		return exitOK
+	}
+	for _, c := range commands {
+		if c.name != args[0] {
+			continue
+		}
+		err := c.run(e, args[1:])
+		switch {
+		case err == nil:
+			return exitOK
+		case errors.Is(err, flag.ErrHelp):
+			fmt.Fprintf(stderr, "usage : groupie-tracker %s %s\n", c.name, c.args)
+			if e.flags != nil {
+				e.flags.SetOutput(stderr)
+				e.flags.PrintDefaults()
+			}
+			return exitUsage
+		case errors.Is(err, errUsage):
+			if fe, ok := err.(flagError); ok {
+				fmt.Fprintln(stderr, "erreur :", fe)
+			}
+			fmt.Fprintf(stderr, "usage : groupie-tracker %s %s\n", c.name, c.args)
+			return exitUsage
+		default:
+			fmt.Fprintln(stderr, "erreur :", err)
+			return exitError
+		}
+	}
+	fmt.Fprintf(stderr, "commande inconnue : %s\n", args[0])
+	runHelp(e, nil)
+	return exitUsage
+}
+
+func runHelp(e *env, _ []string) error {
+	fmt.Fprintln(e.stdout, "Groupie Tracker - mode ligne de commande")
+	fmt.Fprintln(e.stdout)
+	fmt.Fprintln(e.stdout, "Sans commande, l'application graphique est lancée. Une route l'ouvre")
+	fmt.Fprintln(e.stdout, "directement sur une vue : groupie-tracker artist/12")
+	fmt.Fprintln(e.stdout, "  artists, artist/<id>, member/<nom>, compare/<id>,<id>, stats, map,")
+	fmt.Fprintln(e.stdout, "  cities, calendar, members, collections, new")
+	fmt.Fprintln(e.stdout)
+	fmt.Fprintln(e.stdout, "Commandes :")
+	for _, c := range commands {
+		fmt.Fprintf(e.stdout, "  %-9s %-26s %s\n", c.name, c.args, c.help)
+	}
+	fmt.Fprintln(e.stdout)
+	fmt.Fprintln(e.stdout, "Filtres (list, search, export) :")
+	fmt.Fprintln(e.stdout, "  -min-creation / -max-creation  année de création")
+	fmt.Fprintln(e.stdout, "  -min-album / -max-album        année du premier album")
+	fmt.Fprintln(e.stdout, "  -members 1,2,8                 nombre de membres (8 = 8 et plus)")
+	fmt.Fprintln(e.stdout, "  -location texte                ville ou pays de concert")
+	fmt.Fprintln(e.stdout, "  -favorites, -tag, -min-rating  favoris")
+	fmt.Fprintln(e.stdout, "  -collection nom                artistes d'une collection")
+	fmt.Fprintf(e.stdout, "  -sort %s\n", sortNames())
+	fmt.Fprintln(e.stdout, "  -format table|json|csv")
+	return nil
+}
+
+// criteriaFlags déclare les options de filtrage communes à list, search et export
+type criteriaFlags struct {
+	minCreation, maxCreation int
+	minAlbum, maxAlbum       int
+	members                  string
+	location                 string
+	favorites                bool
+	tag                      string
+	minRating                int
+	collection               string
+	sort                     string
+}
+
+func (cf *criteriaFlags) register(fs *flag.FlagSet) {
+	fs.IntVar(&cf.minCreation, "min-creation", 0, "année de création minimale")
+	fs.IntVar(&cf.maxCreation, "max-creation", 0, "année de création maximale")
+	fs.IntVar(&cf.minAlbum, "min-album", 0, "année minimale du premier album")
+	fs.IntVar(&cf.maxAlbum, "max-album", 0, "année maximale du premier album")
+	fs.StringVar(&cf.members, "members", "", "nombres de membres, séparés par des virgules")
+	fs.StringVar(&cf.location, "location", "", "lieu de concert")
+	fs.BoolVar(&cf.favorites, "favorites", false, "uniquement les favoris")
+	fs.StringVar(&cf.tag, "tag", "", "tag d'un favori")
+	fs.IntVar(&cf.minRating, "min-rating", 0, "note minimale (1 à 5)")
+	fs.StringVar(&cf.collection, "collection", "", "nom d'une collection")
+	fs.StringVar(&cf.sort, "sort", string(filter.SortNameAsc), "ordre de tri")
+}
+
+func (cf *criteriaFlags) criteria() (filter.Criteria, error) {
+	c := filter.Criteria{
+		MinCreation:   cf.minCreation,
+		MaxCreation:   cf.maxCreation,
+		MinAlbum:      cf.minAlbum,
+		MaxAlbum:      cf.maxAlbum,
+		Location:      cf.location,
+		FavoritesOnly: cf.favorites,
+		Tag:           cf.tag,
+		MinRating:     cf.minRating,
+	}
+	for _, s := range strings.Split(cf.members, ",") {
+		s = strings.TrimSuffix(strings.TrimSpace(s), "+")
+		if s == "" {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			return c, fmt.Errorf("nombre de membres invalide : %q", s)
+		}
+		c.Members = append(c.Members, min(n, filter.MembersMax))
+	}
+
+	key, err := parseSort(cf.sort)
+	if err != nil {
+		return c, err
+	}
+	c.Sort = key
+
+	if cf.collection != "" {
+		ids, err := catalog.CollectionIDs(cf.collection)
+		if err != nil {
+			return c, err
+		}
+		c.ArtistIDs = ids
+	}
+	return c, nil
+}
+
+func parseSort(name string) (filter.SortKey, error) {
+	for _, k := range filter.SortKeys {
+		if string(k) == name {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("tri inconnu : %q (%s)", name, sortNames())
+}
+
+func sortNames() string {
+	names := make([]string, len(filter.SortKeys))
+	for i, k := range filter.SortKeys {
+		names[i] = string(k)
+	}
+	return strings.Join(names, "|")
+}
+
+// parseArgs accepte les options avant ou après les arguments : "search queen -format json"
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); errors.Is(err, flag.ErrHelp) {
+			return nil, err
+		} else if err != nil {
+			return nil, flagError{err}
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// newFlagSet crée les options d'une commande. Le FlagSet n'écrit rien : Run
+// affiche l'erreur une seule fois, suivie de l'usage de la commande.
+func newFlagSet(e *env, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	e.flags = fs
+	return fs
+}
+
+// filtered charge le catalogue et applique les filtres
+func filtered(c filter.Criteria) (*catalog.Catalog, []models.Artist, map[int]models.Favorite, error) {
+	cat, err := catalog.Load(c.Location != "")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	favs, err := store.LoadFavoriteEntries()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return cat, cat.Index().Apply(c, favs), favs, nil
+}
+
+func runList(e *env, args []string) error {
+	return listArtists(e, "list", args, false)
+}
+
+func runSearch(e *env, args []string) error {
+	return listArtists(e, "search", args, true)
+}
+
+func listArtists(e *env, name string, args []string, needsQuery bool) error {
+	fs := newFlagSet(e, name)
+	var cf criteriaFlags
+	cf.register(fs)
+	format := fs.String("format", "table", "table, json ou csv")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if needsQuery != (len(rest) > 0) {
+		return errUsage
+	}
+
+	c, err := cf.criteria()
+	if err != nil {
+		return err
+	}
+	c.Search = strings.Join(rest, " ")
+
+	_, artists, favs, err := filtered(c)
+	if err != nil {
+		return err
+	}
+	return writeArtists(e.stdout, *format, artists, favs)
+}
+
+func runShow(e *env, args []string) error {
+	fs := newFlagSet(e, "show")
+	format := fs.String("format", "table", "table ou json")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		return errUsage
+	}
+
+	cat, artist, err := findArtist(strings.Join(rest, " "))
+	if err != nil {
+		return err
+	}
+	concerts, err := cat.Concerts(artist.ID)
+	if err != nil {
+		return err
+	}
+	favs, _ := store.LoadFavoriteEntries()
+	fav, isFav := favs[artist.ID]
+	if !isFav {
+		return writeDetail(e.stdout, *format, artist, nil, concerts)
+	}
+	return writeDetail(e.stdout, *format, artist, &fav, concerts)
+}
+
+func runConcerts(e *env, args []string) error {
+	fs := newFlagSet(e, "concerts")
+	format := fs.String("format", "table", "table, json, csv, ics, geojson ou kml")
+	tour := fs.Bool("tour", false, "geojson et kml : relie les concerts dans l'ordre chronologique")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		return errUsage
+	}
+
+	cat, artist, err := findArtist(strings.Join(rest, " "))
+	if err != nil {
+		return err
+	}
+	concerts, err := cat.Concerts(artist.ID)
+	if err != nil {
+		return err
+	}
+	if isGeoFormat(*format) {
+		return writeGeo(e, e.stdout, *format, artist.Name, []models.Artist{artist}, map[int][]models.Concert{artist.ID: concerts}, *tour)
+	}
+	return writeConcerts(e.stdout, *format, artist, concerts)
+}
+
+func runExport(e *env, args []string) error {
+	fs := newFlagSet(e, "export")
+	var cf criteriaFlags
+	cf.register(fs)
+	format := fs.String("format", "json", "json, csv, ics, geojson ou kml")
+	tour := fs.Bool("tour", false, "geojson et kml : relie les concerts dans l'ordre chronologique")
+	output := fs.String("o", "", "fichier de sortie (sortie standard par défaut)")
+	search := fs.String("search", "", "nom d'artiste ou de membre")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return errUsage
+	}
+
+	c, err := cf.criteria()
+	if err != nil {
+		return err
+	}
+	c.Search = *search
+
+	cat, artists, favs, err := filtered(c)
+	if err != nil {
+		return err
+	}
+	concerts, err := cat.AllConcerts()
+	if err != nil {
+		return err
+	}
+
+	write := func(w io.Writer) error {
+		if isGeoFormat(*format) {
+			return writeGeo(e, w, *format, "Groupie Tracker", artists, concerts, *tour)
+		}
+		return writeExport(w, *format, artists, favs, concerts)
+	}
+	if *output == "" {
+		return write(e.stdout)
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	err = write(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stderr, "%d artistes exportés dans %s\n", len(artists), *output)
+	return nil
+}
+
+func runTUI(e *env, args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+	return tui.Run()
+}
+
+func runWeb(e *env, args []string) error {
+	fs := newFlagSet(e, "web")
+	addr := fs.String("addr", web.DefaultAddr, "adresse d'écoute")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return errUsage
+	}
+	return web.ListenAndServe(*addr)
+}
+
+func runProxy(e *env, args []string) error {
+	fs := newFlagSet(e, "proxy")
+	addr := fs.String("addr", proxy.DefaultAddr, "adresse d'écoute")
+	ttl := fs.Duration("ttl", proxy.DefaultTTL, "durée de réutilisation des données de l'API")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 || *ttl <= 0 {
+		return errUsage
+	}
+	return proxy.ListenAndServe(*addr, *ttl)
+}
+
+func findArtist(ref string) (*catalog.Catalog, models.Artist, error) {
+	cat, err := catalog.Load(false)
+	if err != nil {
+		return nil, models.Artist{}, err
+	}
+	artist, ok := cat.Find(ref)
+	if !ok {
+		return nil, models.Artist{}, fmt.Errorf("artiste introuvable : %q", ref)
+	}
+	return cat, artist, nil
+}
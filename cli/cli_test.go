@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"groupie-tracker/models"
+	"groupie-tracker/store"
+)
+
+// fakeAPI remplace l'API distante : les requêtes sortantes (http.Get) sont
+// servies par mux sans passer par le réseau
+type fakeAPI struct{ mux *http.ServeMux }
+
+func (f fakeAPI) RoundTrip(r *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	f.mux.ServeHTTP(rec, r)
+	return rec.Result(), nil
+}
+
+var testRelations = map[int]map[string][]string{
+	1: {"london-uk": {"01-06-1975"}, "paris-france": {"03-06-1975"}},
+	2: {"lyon-france": {"05-07-2010"}},
+	3: {"stockholm-sweden": {"01-01-1979"}},
+}
+
+// useFakeAPI sert trois artistes factices ; tant que locationsDown est vrai,
+// /api/locations répond par une erreur
+func useFakeAPI(t *testing.T) (locationsDown *atomic.Bool) {
+	t.Helper()
+	t.Setenv("GROUPIE_TRACKER_HOME", t.TempDir())
+	locationsDown = new(atomic.Bool)
+
+	artists := []models.Artist{
+		{ID: 1, Name: "Queen", Members: []string{"Freddie Mercury", "Brian May", "Roger Taylor", "John Deacon"}, CreationDate: 1970, FirstAlbum: "14-12-1973"},
+		{ID: 2, Name: "Gorillaz", Members: []string{"Damon Albarn", "Jamie Hewlett"}, CreationDate: 1998, FirstAlbum: "26-03-2001"},
+		{ID: 3, Name: "ABBA", Members: []string{"Agnetha", "Björn", "Benny", "Anni-Frid"}, CreationDate: 1972, FirstAlbum: "26-03-1973"},
+	}
+	type index struct {
+		ID             int                 `json:"id"`
+		Locations      []string            `json:"locations,omitempty"`
+		DatesLocations map[string][]string `json:"datesLocations,omitempty"`
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/artists", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(artists)
+	})
+	mux.HandleFunc("GET /api/locations", func(w http.ResponseWriter, r *http.Request) {
+		if locationsDown.Load() {
+			http.Error(w, "indisponible", http.StatusServiceUnavailable)
+			return
+		}
+		var out struct {
+			Index []index `json:"index"`
+		}
+		for id, rel := range testRelations {
+			var locs []string
+			for loc := range rel {
+				locs = append(locs, loc)
+			}
+			out.Index = append(out.Index, index{ID: id, Locations: locs})
+		}
+		json.NewEncoder(w).Encode(out)
+	})
+	mux.HandleFunc("GET /api/relation", func(w http.ResponseWriter, r *http.Request) {
+		var out struct {
+			Index []index `json:"index"`
+		}
+		for id, rel := range testRelations {
+			out.Index = append(out.Index, index{ID: id, DatesLocations: rel})
+		}
+		json.NewEncoder(w).Encode(out)
+	})
+	mux.HandleFunc("GET /api/relation/{id}", func(w http.ResponseWriter, r *http.Request) {
+		for id, rel := range testRelations {
+			if r.PathValue("id") == strconv.Itoa(id) {
+				json.NewEncoder(w).Encode(index{ID: id, DatesLocations: rel})
+				return
+			}
+		}
+		http.NotFound(w, r)
+	})
+
+	saved := http.DefaultTransport
+	http.DefaultTransport = fakeAPI{mux}
+	t.Cleanup(func() { http.DefaultTransport = saved })
+
+	if _, err := store.UpdateFavorite(2, func(f *models.Favorite) { f.Rating = 4; f.Tags = []string{"pop"} }); err != nil {
+		t.Fatal(err)
+	}
+	return locationsDown
+}
+
+func run(args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	code = Run(args, &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func TestRun(t *testing.T) {
+	useFakeAPI(t)
+
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout []string // extraits attendus
+		stderr []string
+	}{
+		{"aide", nil, exitOK, []string{"Commandes :", "concerts"}, nil},
+		{"aide -h", []string{"--help"}, exitOK, []string{"Commandes :"}, nil},
+		{"commande inconnue", []string{"jouer"}, exitUsage, []string{"Commandes :"}, []string{"commande inconnue : jouer"}},
+		{"liste", []string{"list"}, exitOK, []string{"ABBA", "Gorillaz", "♥ ★★★★", "3 artiste(s)"}, nil},
+		{"options après les arguments", []string{"search", "queen", "-format", "csv"}, exitOK, []string{"1,Queen,1970"}, nil},
+		{"recherche par membre", []string{"search", "albarn"}, exitOK, []string{"Gorillaz", "1 artiste(s)"}, nil},
+		{"recherche sans texte", []string{"search"}, exitUsage, nil, []string{"usage : groupie-tracker search"}},
+		{"fiche", []string{"show", "queen"}, exitOK, []string{"Freddie Mercury", "Concerts       2", "London, UK"}, nil},
+		{"fiche d'un favori", []string{"show", "2"}, exitOK, []string{"Tags", "pop"}, nil},
+		{"artiste introuvable", []string{"show", "Beatles"}, exitError, nil, []string{`erreur : artiste introuvable : "Beatles"`}},
+		{"concerts", []string{"concerts", "1", "-format", "csv"}, exitOK, []string{"artistId,artist,date,location", "1,Queen,1975-06-03,\"Paris, France\""}, nil},
+		{"agenda", []string{"concerts", "queen", "-format", "ics"}, exitOK, []string{"BEGIN:VCALENDAR", "LOCATION:London\\, UK"}, nil},
+		{"format inconnu", []string{"list", "-format", "xml"}, exitError, nil, []string{`format inconnu : "xml"`}},
+		{"tri inconnu", []string{"list", "-sort", "hasard"}, exitError, nil, []string{"tri inconnu"}},
+		{"membres invalides", []string{"list", "-members", "deux"}, exitError, nil, []string{"nombre de membres invalide"}},
+		{"argument en trop", []string{"export", "queen"}, exitUsage, nil, []string{"usage : groupie-tracker export"}},
+		{"valeur d'option invalide", []string{"list", "-min-creation", "abc"}, exitUsage, nil, []string{"invalid value", "usage : groupie-tracker list"}},
+		{"aide d'une commande", []string{"list", "-h"}, exitUsage, nil, []string{"usage : groupie-tracker list", "-min-creation"}},
+		{"ttl invalide", []string{"proxy", "-ttl", "0s"}, exitUsage, nil, []string{"usage : groupie-tracker proxy"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := run(tt.args...)
+			if code != tt.code {
+				t.Errorf("code %d, attendu %d\nstdout: %s\nstderr: %s", code, tt.code, stdout, stderr)
+			}
+			for _, want := range tt.stdout {
+				if !strings.Contains(stdout, want) {
+					t.Errorf("stdout sans %q :\n%s", want, stdout)
+				}
+			}
+			for _, want := range tt.stderr {
+				if !strings.Contains(stderr, want) {
+					t.Errorf("stderr sans %q :\n%s", want, stderr)
+				}
+			}
+		})
+	}
+}
+
+// Une option inconnue est signalée une seule fois, suivie d'une seule ligne d'usage
+func TestFlagErrorPrintedOnce(t *testing.T) {
+	useFakeAPI(t)
+
+	code, stdout, stderr := run("list", "-inconnue")
+	if code != exitUsage || stdout != "" {
+		t.Errorf("code %d, stdout %q", code, stdout)
+	}
+	want := "erreur : flag provided but not defined: -inconnue\nusage : groupie-tracker list [filtres]\n"
+	if stderr != want {
+		t.Errorf("stderr = %q, attendu %q", stderr, want)
+	}
+}
+
+func TestFilters(t *testing.T) {
+	locationsDown := useFakeAPI(t)
+
+	tests := []struct {
+		args []string
+		want []int
+	}{
+		{[]string{"-sort", "creation"}, []int{1, 3, 2}},
+		{[]string{"-min-creation", "1971", "-max-creation", "1980"}, []int{3}},
+		{[]string{"-members", "2"}, []int{2}},
+		{[]string{"-members", "4"}, []int{3, 1}},
+		{[]string{"-location", "france"}, []int{2, 1}},
+		{[]string{"-location", "sweden"}, []int{3}},
+		{[]string{"-favorites"}, []int{2}},
+		{[]string{"-tag", "pop", "-min-rating", "4"}, []int{2}},
+		{[]string{"-min-rating", "5"}, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			code, stdout, stderr := run(append([]string{"list", "-format", "json"}, tt.args...)...)
+			if code != exitOK {
+				t.Fatalf("code %d : %s", code, stderr)
+			}
+			var records []artistRecord
+			if err := json.Unmarshal([]byte(stdout), &records); err != nil {
+				t.Fatal(err)
+			}
+			got := []int{}
+			for _, r := range records {
+				got = append(got, r.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("IDs = %v, attendu %v", got, tt.want)
+			}
+		})
+	}
+
+	// Sans la liste des lieux, le filtre par lieu échoue au lieu de ne rien trouver
+	locationsDown.Store(true)
+	if code, stdout, stderr := run("list", "-location", "france"); code != exitError || !strings.Contains(stderr, "status 503") {
+		t.Errorf("code %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+}
+
+func TestExport(t *testing.T) {
+	useFakeAPI(t)
+
+	code, stdout, stderr := run("export", "-format", "csv", "-search", "queen")
+	if code != exitOK {
+		t.Fatalf("code %d : %s", code, stderr)
+	}
+	rows, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Une ligne par concert, après l'en-tête
+	if len(rows) != 3 || rows[0][len(rows[0])-1] != "concertLocation" || rows[1][1] != "Queen" || rows[1][9] != "London, UK" {
+		t.Errorf("CSV = %q", rows)
+	}
+
+	code, stdout, stderr = run("export", "-favorites")
+	if code != exitOK {
+		t.Fatalf("code %d : %s", code, stderr)
+	}
+	var records []artistRecord
+	if err := json.Unmarshal([]byte(stdout), &records); err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Favorite == nil || records[0].Favorite.Rating != 4 || len(records[0].Concerts) != 1 {
+		t.Errorf("JSON = %+v", records)
+	}
+}
@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"groupie-tracker/api"
+	"groupie-tracker/export"
+	"groupie-tracker/models"
+)
+
+// artistRecord est la forme JSON d'un artiste, avec ses informations de favori
+type artistRecord struct {
+	models.Artist
+	Favorite *models.Favorite `json:"favorite,omitempty"`
+	Concerts []models.Concert `json:"concerts,omitempty"`
+}
+
+func newRecord(a models.Artist, favs map[int]models.Favorite) artistRecord {
+	r := artistRecord{Artist: a}
+	if fav, ok := favs[a.ID]; ok {
+		r.Favorite = &fav
+	}
+	return r
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func unknownFormat(format string) error {
+	return fmt.Errorf("format inconnu : %q", format)
+}
+
+func stars(rating int) string {
+	return strings.Repeat("★", rating)
+}
+
+var artistCSVHeader = []string{"id", "name", "creationDate", "firstAlbum", "members", "favorite", "rating", "tags"}
+
+func artistCSVRow(a models.Artist, favs map[int]models.Favorite) []string {
+	fav, isFav := favs[a.ID]
+	return []string{
+		strconv.Itoa(a.ID),
+		a.Name,
+		strconv.Itoa(a.CreationDate),
+		a.FirstAlbum,
+		strings.Join(a.Members, "; "),
+		strconv.FormatBool(isFav),
+		strconv.Itoa(fav.Rating),
+		strings.Join(fav.Tags, "; "),
+	}
+}
+
+func writeArtists(w io.Writer, format string, artists []models.Artist, favs map[int]models.Favorite) error {
+	switch format {
+	case "json":
+		records := make([]artistRecord, len(artists))
+		for i, a := range artists {
+			records[i] = newRecord(a, favs)
+		}
+		return writeJSON(w, records)
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write(artistCSVHeader)
+		for _, a := range artists {
+			cw.Write(artistCSVRow(a, favs))
+		}
+		cw.Flush()
+		return cw.Error()
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNOM\tCRÉATION\tPREMIER ALBUM\tMEMBRES\tFAVORI")
+		for _, a := range artists {
+			favCol := ""
+			if fav, ok := favs[a.ID]; ok {
+				favCol = "♥ " + stars(fav.Rating)
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%d\t%s\n", a.ID, a.Name, a.CreationDate, a.FirstAlbum, len(a.Members), favCol)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "\n%d artiste(s)\n", len(artists))
+		return nil
+	}
+	return unknownFormat(format)
+}
+
+func writeDetail(w io.Writer, format string, a models.Artist, fav *models.Favorite, concerts []models.Concert) error {
+	switch format {
+	case "json":
+		return writeJSON(w, artistRecord{Artist: a, Favorite: fav, Concerts: concerts})
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "Nom\t%s\n", a.Name)
+		fmt.Fprintf(tw, "ID\t%d\n", a.ID)
+		fmt.Fprintf(tw, "Création\t%d\n", a.CreationDate)
+		fmt.Fprintf(tw, "Premier album\t%s\n", a.FirstAlbum)
+		fmt.Fprintf(tw, "Membres\t%s\n", strings.Join(a.Members, ", "))
+		if fav != nil {
+			since := "oui"
+			if !fav.AddedAt.IsZero() {
+				since = "depuis le " + fav.AddedAt.Format("02/01/2006")
+			}
+			fmt.Fprintf(tw, "Favori\t%s %s\n", since, stars(fav.Rating))
+			if len(fav.Tags) > 0 {
+				fmt.Fprintf(tw, "Tags\t%s\n", strings.Join(fav.Tags, ", "))
+			}
+			if fav.Note != "" {
+				fmt.Fprintf(tw, "Note\t%s\n", fav.Note)
+			}
+		}
+		fmt.Fprintf(tw, "Concerts\t%d\n", len(concerts))
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		if len(concerts) > 0 {
+			fmt.Fprintln(w)
+			return writeConcerts(w, "table", a, concerts)
+		}
+		return nil
+	}
+	return unknownFormat(format)
+}
+
+func writeConcerts(w io.Writer, format string, a models.Artist, concerts []models.Concert) error {
+	switch format {
+	case "json":
+		if concerts == nil {
+			concerts = []models.Concert{}
+		}
+		return writeJSON(w, concerts)
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"artistId", "artist", "date", "location"})
+		for _, c := range concerts {
+			cw.Write([]string{strconv.Itoa(a.ID), a.Name, c.Date.Format("2006-01-02"), api.LocationLabel(c.Location)})
+		}
+		cw.Flush()
+		return cw.Error()
+	case "ics":
+		return export.Calendar(w, a.Name, []models.Artist{a}, map[int][]models.Concert{a.ID: concerts})
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "DATE\tLIEU")
+		for _, c := range concerts {
+			fmt.Fprintf(tw, "%s\t%s\n", c.Date.Format("02/01/2006"), api.LocationLabel(c.Location))
+		}
+		return tw.Flush()
+	}
+	return unknownFormat(format)
+}
+
+// writeExport écrit les artistes avec leurs concerts ; en CSV une ligne par concert,
+// en iCalendar un événement par concert
+func writeExport(w io.Writer, format string, artists []models.Artist, favs map[int]models.Favorite, concerts map[int][]models.Concert) error {
+	switch format {
+	case "json":
+		records := make([]artistRecord, len(artists))
+		for i, a := range artists {
+			records[i] = newRecord(a, favs)
+			records[i].Concerts = concerts[a.ID]
+		}
+		return writeJSON(w, records)
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write(append(append([]string(nil), artistCSVHeader...), "concertDate", "concertLocation"))
+		for _, a := range artists {
+			row := artistCSVRow(a, favs)
+			if len(concerts[a.ID]) == 0 {
+				cw.Write(append(row, "", ""))
+			}
+			for _, c := range concerts[a.ID] {
+				cw.Write(append(row[:len(row):len(row)], c.Date.Format("2006-01-02"), api.LocationLabel(c.Location)))
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case "ics":
+		return export.Calendar(w, "Groupie Tracker", artists, concerts)
+	}
+	return unknownFormat(format)
+}
+
+func isGeoFormat(format string) bool {
+	return format == string(export.GeoJSON) || format == string(export.KML)
+}
+
+// writeGeo géocode les lieux des concerts (une requête par seconde au plus,
+// avancement sur la sortie d'erreur) puis les écrit en GeoJSON ou KML
+func writeGeo(e *env, w io.Writer, format, name string, artists []models.Artist, concerts map[int][]models.Concert, tour bool) error {
+	points, missing := export.Locate(concerts, api.GetCoordinates, func(done, total int) {
+		fmt.Fprintf(e.stderr, "\rGéocodage des lieux : %d/%d", done, total)
+		if done == total {
+			fmt.Fprintln(e.stderr)
+		}
+	})
+	if len(missing) > 0 {
+		labels := make([]string, len(missing))
+		for i, m := range missing {
+			labels[i] = api.LocationLabel(m)
+		}
+		fmt.Fprintf(e.stderr, "%d lieu(x) introuvable(s), ignoré(s) : %s\n", len(missing), strings.Join(labels, ", "))
+	}
+	return export.Geo(w, export.Format(format), name, artists, concerts, points, tour)
+}
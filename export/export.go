@@ -0,0 +1,142 @@
+// Package export écrit les artistes affichés dans des formats d'échange
+// (CSV, JSON, tableau Markdown) pour les réutiliser hors de l'application.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"groupie-tracker/api"
+	"groupie-tracker/models"
+)
+
+// Format est un format d'export, identifié par son extension de fichier
+type Format string
+
+const (
+	CSV      Format = "csv"
+	JSON     Format = "json"
+	Markdown Format = "md"
+)
+
+// Formats liste les formats dans l'ordre du menu
+var Formats = []Format{CSV, JSON, Markdown}
+
+// Extension retourne l'extension du fichier, point compris
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// Label est le nom affiché du format
+func (f Format) Label() string {
+	switch f {
+	case CSV:
+		return "CSV"
+	case JSON:
+		return "JSON"
+	case Markdown:
+		return "Markdown"
+	case ICalendar:
+		return "iCalendar"
+	case GeoJSON:
+		return "GeoJSON"
+	case KML:
+		return "KML"
+	}
+	return string(f)
+}
+
+// artistRow est une ligne d'export : les lieux sont déjà mis en forme
+type artistRow struct {
+	ID           int      `json:"id"`
+	Name         string   `json:"name"`
+	Members      []string `json:"members"`
+	CreationDate int      `json:"creationDate"`
+	FirstAlbum   string   `json:"firstAlbum"`
+	Locations    []string `json:"locations"`
+	Favorite     bool     `json:"favorite"`
+}
+
+func rows(artists []models.Artist, locations map[int][]string, favs map[int]models.Favorite) []artistRow {
+	out := make([]artistRow, len(artists))
+	for i, a := range artists {
+		labels := make([]string, len(locations[a.ID]))
+		for j, l := range locations[a.ID] {
+			labels[j] = api.LocationLabel(l)
+		}
+		members := a.Members
+		if members == nil {
+			members = []string{}
+		}
+		_, isFav := favs[a.ID]
+		out[i] = artistRow{
+			ID:           a.ID,
+			Name:         a.Name,
+			Members:      members,
+			CreationDate: a.CreationDate,
+			FirstAlbum:   a.FirstAlbum,
+			Locations:    labels,
+			Favorite:     isFav,
+		}
+	}
+	return out
+}
+
+// Artists écrit les artistes dans l'ordre reçu, avec leurs lieux de concert
+// et l'indication de favori
+func Artists(w io.Writer, format Format, artists []models.Artist, locations map[int][]string, favs map[int]models.Favorite) error {
+	list := rows(artists, locations, favs)
+	switch format {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	case CSV:
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"id", "name", "members", "creationDate", "firstAlbum", "locations", "favorite"})
+		for _, r := range list {
+			cw.Write([]string{
+				strconv.Itoa(r.ID),
+				r.Name,
+				strings.Join(r.Members, "; "),
+				strconv.Itoa(r.CreationDate),
+				r.FirstAlbum,
+				strings.Join(r.Locations, "; "),
+				strconv.FormatBool(r.Favorite),
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	case Markdown:
+		var b strings.Builder
+		b.WriteString("| Nom | Membres | Création | Premier album | Lieux | Favori |\n")
+		b.WriteString("|-----|---------|----------|---------------|-------|--------|\n")
+		for _, r := range list {
+			fav := ""
+			if r.Favorite {
+				fav = "♥"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %d | %s | %s | %s |\n",
+				markdownCell(r.Name),
+				markdownCell(strings.Join(r.Members, ", ")),
+				r.CreationDate,
+				markdownCell(r.FirstAlbum),
+				markdownCell(strings.Join(r.Locations, "; ")),
+				fav)
+		}
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	return fmt.Errorf("format inconnu : %q", format)
+}
+
+// markdownCell neutralise ce qui casserait une cellule de tableau
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
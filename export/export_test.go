@@ -0,0 +1,244 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"groupie-tracker/api"
+	"groupie-tracker/models"
+	"groupie-tracker/store"
+)
+
+// TestMain prépare un dossier de données contenant un lieu déjà géocodé,
+// comme après un lancement de l'interface
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "groupie-export")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Setenv("GROUPIE_TRACKER_HOME", dir)
+	api.SetGeocodeStore(store.Geocodes{})
+	if err := store.SaveGeocode("paris, france", models.Coordinates{Lat: "48.8566", Lon: "2.3522"}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+var (
+	testArtists = []models.Artist{
+		{ID: 1, Name: "Queen", Members: []string{"Freddie Mercury", "Brian May"}, CreationDate: 1970, FirstAlbum: "14-12-1973"},
+		{ID: 2, Name: "A|B; C", CreationDate: 2001, FirstAlbum: "01-01-2002"},
+	}
+	testConcerts = map[int][]models.Concert{
+		1: {
+			{ArtistID: 1, Location: "paris-france", Date: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)},
+			{ArtistID: 1, Location: "lyon-france", Date: time.Date(2020, 2, 3, 0, 0, 0, 0, time.UTC)},
+			{ArtistID: 1, Location: "north_carolina-usa", Date: time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		2: {
+			{ArtistID: 2, Location: "lyon-france", Date: time.Date(2021, 6, 5, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+)
+
+func TestArtists(t *testing.T) {
+	locations := map[int][]string{1: {"paris-france", "north_carolina-usa"}}
+	favs := map[int]models.Favorite{1: {ID: 1}}
+
+	tests := []struct {
+		format Format
+		want   []string
+	}{
+		{CSV, []string{
+			"id,name,members,creationDate,firstAlbum,locations,favorite\n",
+			"1,Queen,Freddie Mercury; Brian May,1970,14-12-1973,\"Paris, France; North Carolina, USA\",true\n",
+			"2,A|B; C,,2001,01-01-2002,,false\n",
+		}},
+		{JSON, []string{`"name": "Queen"`, `"North Carolina, USA"`, `"favorite": true`, `"members": []`}},
+		{Markdown, []string{"| Queen | Freddie Mercury, Brian May | 1970 |", `| A\|B; C |`, "| ♥ |"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Artists(&buf, tt.format, testArtists, locations, favs); err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("%q absent de :\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+
+	if err := Artists(&bytes.Buffer{}, "pdf", testArtists, locations, favs); err == nil {
+		t.Error("format inconnu accepté")
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Calendar(&buf, "Mes concerts", testArtists, testConcerts); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	if strings.Count(out, "BEGIN:VEVENT") != 4 {
+		t.Errorf("attendu 4 événements :\n%s", out)
+	}
+	// Seul Paris est dans le cache de géocodage enregistré
+	if strings.Count(out, "GEO:") != 1 || !strings.Contains(out, "GEO:48.8566;2.3522\r\n") {
+		t.Errorf("GEO attendu pour Paris seulement :\n%s", out)
+	}
+	for _, want := range []string{
+		"X-WR-CALNAME:Mes concerts\r\n",
+		"UID:1-20200201-paris-france@groupie-tracker\r\n",
+		"DTSTART;VALUE=DATE:20200201\r\n",
+		"DTEND;VALUE=DATE:20200202\r\n",
+		`LOCATION:North Carolina\, USA` + "\r\n",
+		`SUMMARY:A|B\; C – Lyon\, France` + "\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("%q absent de :\n%s", want, out)
+		}
+	}
+	for line := range strings.SplitSeq(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("ligne de %d octets : %q", len(line), line)
+		}
+	}
+}
+
+func TestFoldLine(t *testing.T) {
+	tests := []struct {
+		name, in string
+		lines    int
+	}{
+		{"courte", "SUMMARY:Queen", 1},
+		{"75 octets", strings.Repeat("a", 75), 1},
+		{"76 octets", strings.Repeat("a", 76), 2},
+		{"accents", "DESCRIPTION:" + strings.Repeat("é", 80), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			foldLine(&b, tt.in)
+			lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+			if len(lines) != tt.lines {
+				t.Fatalf("%d lignes, attendu %d : %q", len(lines), tt.lines, lines)
+			}
+			joined := lines[0]
+			for _, l := range lines[1:] {
+				if !strings.HasPrefix(l, " ") {
+					t.Errorf("continuation sans espace : %q", l)
+				}
+				joined += l[1:]
+			}
+			if joined != tt.in {
+				t.Errorf("déplié = %q, attendu %q", joined, tt.in)
+			}
+			for _, l := range lines {
+				if len(l) > 75 || !utf8.ValidString(l) {
+					t.Errorf("ligne invalide : %q", l)
+				}
+			}
+		})
+	}
+}
+
+func TestLocate(t *testing.T) {
+	var asked []string
+	geocode := func(place string) (string, string, error) {
+		asked = append(asked, place)
+		switch place {
+		case "Paris, France":
+			return "48.85", "2.35", nil
+		case "Lyon, France":
+			return "45.76", "4.83", nil
+		}
+		return "", "", fmt.Errorf("not found")
+	}
+	var done int
+	points, missing := Locate(testConcerts, geocode, func(n, total int) { done = n })
+
+	if len(asked) != 3 || done != 3 {
+		t.Errorf("lieux demandés = %v (%d), attendu 3 lieux distincts", asked, done)
+	}
+	if p := points["lyon-france"]; p.Lat != 45.76 || p.Lon != 4.83 {
+		t.Errorf("Lyon = %+v", p)
+	}
+	if len(missing) != 1 || missing[0] != "north_carolina-usa" {
+		t.Errorf("introuvables = %v", missing)
+	}
+}
+
+func TestGeo(t *testing.T) {
+	points := map[string]Point{
+		"paris-france": {Lat: 48.85, Lon: 2.35},
+		"lyon-france":  {Lat: 45.76, Lon: 4.83},
+	}
+
+	tests := []struct {
+		format Format
+		tour   bool
+		check  func(t *testing.T, out []byte)
+	}{
+		{GeoJSON, false, func(t *testing.T, out []byte) {
+			var fc struct {
+				Type     string
+				Features []geoFeature
+			}
+			if err := json.Unmarshal(out, &fc); err != nil {
+				t.Fatal(err)
+			}
+			if fc.Type != "FeatureCollection" || len(fc.Features) != 3 {
+				t.Fatalf("%s avec %d éléments, attendu 3 points", fc.Type, len(fc.Features))
+			}
+			f := fc.Features[0]
+			if f.Properties["city"] != "Paris" || f.Properties["country"] != "France" || f.Properties["date"] != "2020-02-01" {
+				t.Errorf("propriétés = %v", f.Properties)
+			}
+			if c := f.Geometry.Coordinates.([]any); c[0] != 2.35 || c[1] != 48.85 {
+				t.Errorf("coordonnées = %v, attendu [lon, lat]", c)
+			}
+		}},
+		{GeoJSON, true, func(t *testing.T, out []byte) {
+			if n := strings.Count(string(out), `"LineString"`); n != 1 {
+				t.Errorf("%d tracés, attendu 1 (seul Queen a deux lieux localisés)", n)
+			}
+		}},
+		{KML, true, func(t *testing.T, out []byte) {
+			var doc kmlDocument
+			if err := xml.Unmarshal(out, &doc); err != nil {
+				t.Fatal(err)
+			}
+			if doc.Name != "Concerts" || len(doc.Folders) != 2 {
+				t.Fatalf("document %q avec %d dossiers", doc.Name, len(doc.Folders))
+			}
+			queen := doc.Folders[0].Placemarks
+			if len(queen) != 3 || queen[0].Point.Coordinates != "2.35,48.85" || queen[2].LineString == nil {
+				t.Errorf("repères de Queen = %+v", queen)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/tour=%v", tt.format, tt.tour), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Geo(&buf, tt.format, "Concerts", testArtists, testConcerts, points, tt.tour); err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, buf.Bytes())
+		})
+	}
+}
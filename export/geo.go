@@ -0,0 +1,257 @@
+package export
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"groupie-tracker/api"
+	"groupie-tracker/models"
+)
+
+const (
+	GeoJSON Format = "geojson"
+	KML     Format = "kml"
+)
+
+// GeoFormats liste les formats cartographiques dans l'ordre du menu
+var GeoFormats = []Format{GeoJSON, KML}
+
+// Point est une position WGS 84
+type Point struct {
+	Lat, Lon float64
+}
+
+// Geocoder retourne les coordonnées d'un lieu lisible (api.GetCoordinates)
+type Geocoder func(place string) (lat, lon string, err error)
+
+// Locate géocode une fois chaque lieu distinct des concerts. Les positions
+// sont indexées par lieu brut de l'API ; les lieux introuvables sont
+// retournés à part, triés. progress est appelé après chaque lieu (peut être nil).
+func Locate(concerts map[int][]models.Concert, geocode Geocoder, progress func(done, total int)) (map[string]Point, []string) {
+	seen := make(map[string]bool)
+	var places []string
+	for _, list := range concerts {
+		for _, c := range list {
+			if !seen[c.Location] {
+				seen[c.Location] = true
+				places = append(places, c.Location)
+			}
+		}
+	}
+	sort.Strings(places)
+
+	points := make(map[string]Point, len(places))
+	var missing []string
+	for i, place := range places {
+		lat, lon, err := geocode(api.LocationLabel(place))
+		la, errLat := strconv.ParseFloat(lat, 64)
+		lo, errLon := strconv.ParseFloat(lon, 64)
+		if err != nil || errLat != nil || errLon != nil {
+			missing = append(missing, place)
+		} else {
+			points[place] = Point{Lat: la, Lon: lo}
+		}
+		if progress != nil {
+			progress(i+1, len(places))
+		}
+	}
+	return points, missing
+}
+
+// splitLabel sépare "North Carolina, USA" en ville et pays
+func splitLabel(label string) (city, country string) {
+	if i := strings.LastIndex(label, ", "); i >= 0 {
+		return label[:i], label[i+2:]
+	}
+	return label, ""
+}
+
+// tourPoints retourne l'itinéraire chronologique d'un artiste, sans
+// répéter deux fois de suite la même ville
+func tourPoints(concerts []models.Concert, points map[string]Point) []Point {
+	var tour []Point
+	last := ""
+	for _, c := range concerts {
+		p, ok := points[c.Location]
+		if !ok || c.Location == last {
+			continue
+		}
+		tour = append(tour, p)
+		last = c.Location
+	}
+	return tour
+}
+
+type geoFeature struct {
+	Type       string         `json:"type"`
+	Geometry   geoGeometry    `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+type geoGeometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+// geoJSON écrit une FeatureCollection (RFC 7946) : un Point par concert
+// localisé et, si tour est vrai, une LineString par artiste reliant ses
+// concerts dans l'ordre chronologique
+func geoJSON(w io.Writer, artists []models.Artist, concerts map[int][]models.Concert, points map[string]Point, tour bool) error {
+	features := []geoFeature{}
+	for _, a := range artists {
+		for _, c := range concerts[a.ID] {
+			p, ok := points[c.Location]
+			if !ok {
+				continue
+			}
+			city, country := splitLabel(api.LocationLabel(c.Location))
+			features = append(features, geoFeature{
+				Type:     "Feature",
+				Geometry: geoGeometry{Type: "Point", Coordinates: []float64{p.Lon, p.Lat}},
+				Properties: map[string]any{
+					"artistId": a.ID,
+					"artist":   a.Name,
+					"date":     c.Date.Format("2006-01-02"),
+					"city":     city,
+					"country":  country,
+					"location": c.Location,
+				},
+			})
+		}
+		if !tour {
+			continue
+		}
+		if line := tourPoints(concerts[a.ID], points); len(line) >= 2 {
+			coords := make([][]float64, len(line))
+			for i, p := range line {
+				coords[i] = []float64{p.Lon, p.Lat}
+			}
+			features = append(features, geoFeature{
+				Type:     "Feature",
+				Geometry: geoGeometry{Type: "LineString", Coordinates: coords},
+				Properties: map[string]any{
+					"artistId": a.ID,
+					"artist":   a.Name,
+					"kind":     "tour",
+				},
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(struct {
+		Type     string       `json:"type"`
+		Features []geoFeature `json:"features"`
+	}{"FeatureCollection", features})
+}
+
+type kmlDocument struct {
+	XMLName xml.Name    `xml:"http://www.opengis.net/kml/2.2 kml"`
+	Name    string      `xml:"Document>name"`
+	Folders []kmlFolder `xml:"Document>Folder"`
+}
+
+type kmlFolder struct {
+	Name       string         `xml:"name"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name        string         `xml:"name"`
+	Description string         `xml:"description,omitempty"`
+	When        string         `xml:"TimeStamp>when,omitempty"`
+	Data        []kmlData      `xml:"ExtendedData>Data,omitempty"`
+	Point       *kmlCoords     `xml:"Point"`
+	LineString  *kmlLineString `xml:"LineString"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+type kmlCoords struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlLineString struct {
+	Tessellate  int    `xml:"tessellate"`
+	Coordinates string `xml:"coordinates"`
+}
+
+func kmlCoord(p Point) string {
+	return strconv.FormatFloat(p.Lon, 'f', -1, 64) + "," + strconv.FormatFloat(p.Lat, 'f', -1, 64)
+}
+
+// kml écrit un document KML 2.2 avec un dossier par artiste, un
+// repère daté par concert localisé et, si tour est vrai, la ligne de la tournée
+func kml(w io.Writer, name string, artists []models.Artist, concerts map[int][]models.Concert, points map[string]Point, tour bool) error {
+	doc := kmlDocument{Name: name}
+	for _, a := range artists {
+		folder := kmlFolder{Name: a.Name}
+		for _, c := range concerts[a.ID] {
+			p, ok := points[c.Location]
+			if !ok {
+				continue
+			}
+			label := api.LocationLabel(c.Location)
+			city, country := splitLabel(label)
+			folder.Placemarks = append(folder.Placemarks, kmlPlacemark{
+				Name:        label,
+				Description: a.Name + " – " + c.Date.Format("02/01/2006"),
+				When:        c.Date.Format("2006-01-02"),
+				Data: []kmlData{
+					{Name: "artist", Value: a.Name},
+					{Name: "date", Value: c.Date.Format("2006-01-02")},
+					{Name: "city", Value: city},
+					{Name: "country", Value: country},
+				},
+				Point: &kmlCoords{Coordinates: kmlCoord(p)},
+			})
+		}
+		if tour {
+			if line := tourPoints(concerts[a.ID], points); len(line) >= 2 {
+				coords := make([]string, len(line))
+				for i, p := range line {
+					coords[i] = kmlCoord(p)
+				}
+				folder.Placemarks = append(folder.Placemarks, kmlPlacemark{
+					Name:       a.Name + " – tournée",
+					LineString: &kmlLineString{Tessellate: 1, Coordinates: strings.Join(coords, " ")},
+				})
+			}
+		}
+		if len(folder.Placemarks) > 0 {
+			doc.Folders = append(doc.Folders, folder)
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// Geo écrit les concerts dans un format cartographique (GeoJSON ou KML)
+func Geo(w io.Writer, format Format, name string, artists []models.Artist, concerts map[int][]models.Concert, points map[string]Point, tour bool) error {
+	switch format {
+	case GeoJSON:
+		return geoJSON(w, artists, concerts, points, tour)
+	case KML:
+		return kml(w, name, artists, concerts, points, tour)
+	}
+	return fmt.Errorf("format inconnu : %q", format)
+}
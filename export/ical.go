@@ -0,0 +1,95 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"groupie-tracker/api"
+	"groupie-tracker/models"
+)
+
+// ICalendar est l'extension des fichiers de calendrier
+const ICalendar Format = "ics"
+
+// Calendar écrit un calendrier iCalendar (RFC 5545) avec un événement sur
+// la journée par concert. Les coordonnées GEO ne sont ajoutées que pour les
+// lieux déjà géocodés, y compris lors d'un lancement précédent (cache sur
+// disque) : l'export ne déclenche aucune requête réseau.
+func Calendar(w io.Writer, name string, artists []models.Artist, concerts map[int][]models.Concert) error {
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	var b strings.Builder
+	line := func(format string, args ...any) {
+		foldLine(&b, fmt.Sprintf(format, args...))
+	}
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Groupie Tracker//Concerts//FR")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	if name != "" {
+		line("X-WR-CALNAME:%s", icalText(name))
+	}
+	for _, a := range artists {
+		for _, c := range concerts[a.ID] {
+			label := api.LocationLabel(c.Location)
+			day := c.Date.Format("20060102")
+			line("BEGIN:VEVENT")
+			// L'UID est stable : réimporter le fichier met à jour les événements au lieu de les dupliquer
+			line("UID:%d-%s-%s@groupie-tracker", a.ID, day, uidPart(c.Location))
+			line("DTSTAMP:%s", stamp)
+			line("DTSTART;VALUE=DATE:%s", day)
+			line("DTEND;VALUE=DATE:%s", c.Date.AddDate(0, 0, 1).Format("20060102"))
+			line("SUMMARY:%s", icalText(a.Name+" – "+label))
+			line("LOCATION:%s", icalText(label))
+			if lat, lon, ok := api.CachedCoordinates(label); ok {
+				line("GEO:%s;%s", lat, lon)
+			}
+			if len(a.Members) > 0 {
+				line("DESCRIPTION:%s", icalText(strings.Join(a.Members, ", ")))
+			}
+			line("TRANSP:TRANSPARENT")
+			line("END:VEVENT")
+		}
+	}
+	line("END:VCALENDAR")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// icalText échappe une valeur de type TEXT
+func icalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "").Replace(s)
+}
+
+// uidPart garde les caractères sûrs d'un lieu pour l'UID
+func uidPart(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// foldLine écrit une ligne terminée par CRLF, repliée à 75 octets sans
+// couper de caractère UTF-8
+func foldLine(b *strings.Builder, s string) {
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		limit = 74 // l'espace de continuation compte
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,236 @@
+// Package filter contient la recherche, les filtres et les tris de la liste
+// des artistes. Il est partagé par l'interface graphique et les autres modes
+// (ligne de commande, serveur web...).
+package filter
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"groupie-tracker/models"
+)
+
+// SortKey identifie un ordre de tri
+type SortKey string
+
+const (
+	SortNameAsc       SortKey = "name"
+	SortNameDesc      SortKey = "name-desc"
+	SortCreationNew   SortKey = "creation-desc"
+	SortCreationOld   SortKey = "creation"
+	SortFirstAlbumNew SortKey = "album-desc"
+	SortFirstAlbumOld SortKey = "album"
+)
+
+// SortKeys liste les tris disponibles, dans l'ordre du menu
+var SortKeys = []SortKey{SortNameAsc, SortNameDesc, SortCreationNew, SortCreationOld, SortFirstAlbumNew, SortFirstAlbumOld}
+
+// MembersMax regroupe les groupes de MembersMax membres ou plus ("8+")
+const MembersMax = 8
+
+// Criteria décrit une recherche. Les valeurs nulles désactivent le filtre correspondant.
+type Criteria struct {
+	Search        string // nom du groupe ou d'un membre
+	MinCreation   int    // année de création
+	MaxCreation   int    // 0 = pas de borne
+	MinAlbum      int    // année du premier album
+	MaxAlbum      int    // 0 = pas de borne
+	Members       []int  // nombres de membres acceptés (MembersMax = "8+")
+	Location      string // ville ou pays de concert
+	FavoritesOnly bool
+	Tag           string       // tag d'un favori
+	MinRating     int          // note minimale d'un favori
+	ArtistIDs     map[int]bool // restreint à ces artistes (collection), nil = tous
+	Sort          SortKey
+}
+
+// entry contient les champs précalculés d'un artiste
+type entry struct {
+	artist    models.Artist
+	name      string
+	members   []string
+	albumYear int
+	albumDate time.Time
+	locations []string
+}
+
+// Index précalcule les champs de recherche (minuscules, années, lieux nettoyés)
+// pour ne pas les recalculer à chaque frappe.
+type Index struct {
+	entries []entry
+}
+
+// NewIndex construit l'index des artistes ; locations associe un ID d'artiste à ses lieux de concert
+func NewIndex(artists []models.Artist, locations map[int][]string) *Index {
+	idx := &Index{entries: make([]entry, 0, len(artists))}
+	for _, a := range artists {
+		e := entry{
+			artist:    a,
+			name:      strings.ToLower(a.Name),
+			albumYear: AlbumYear(a.FirstAlbum),
+		}
+		e.albumDate, _ = time.Parse("02-01-2006", a.FirstAlbum)
+		for _, m := range a.Members {
+			e.members = append(e.members, strings.ToLower(strings.TrimSpace(m)))
+		}
+		for _, l := range locations[a.ID] {
+			e.locations = append(e.locations, strings.ToLower(CleanLocation(l)))
+		}
+		idx.entries = append(idx.entries, e)
+	}
+	return idx
+}
+
+// Len retourne le nombre d'artistes indexés
+func (idx *Index) Len() int {
+	return len(idx.entries)
+}
+
+// AlbumYear extrait l'année d'une date "jj-mm-aaaa" (0 si illisible)
+func AlbumYear(firstAlbum string) int {
+	if len(firstAlbum) < 4 {
+		return 0
+	}
+	year, _ := strconv.Atoi(firstAlbum[len(firstAlbum)-4:])
+	return year
+}
+
+// CleanLocation rend un lieu de l'API lisible : "north_carolina-usa" -> "north carolina usa"
+func CleanLocation(loc string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(loc, "-", " "), "_", " ")
+}
+
+// Apply filtre puis trie les artistes ; favs contient les favoris de l'utilisateur
+func (idx *Index) Apply(c Criteria, favs map[int]models.Favorite) []models.Artist {
+	search := strings.ToLower(strings.TrimSpace(c.Search))
+	location := strings.ToLower(strings.TrimSpace(c.Location))
+	tag := strings.ToLower(strings.TrimSpace(c.Tag))
+	maxCreation := c.MaxCreation
+	if maxCreation == 0 {
+		maxCreation = 3000
+	}
+	maxAlbum := c.MaxAlbum
+	if maxAlbum == 0 {
+		maxAlbum = 3000
+	}
+	members := make(map[int]bool, len(c.Members))
+	for _, n := range c.Members {
+		members[n] = true
+	}
+
+	matched := make([]*entry, 0, len(idx.entries))
+	for i := range idx.entries {
+		e := &idx.entries[i]
+		a := e.artist
+
+		fav, isFav := favs[a.ID]
+		if c.FavoritesOnly && !isFav {
+			continue
+		}
+		if tag != "" && !containsAny(fav.Tags, tag) {
+			continue
+		}
+		if c.MinRating > 0 && fav.Rating < c.MinRating {
+			continue
+		}
+		if c.ArtistIDs != nil && !c.ArtistIDs[a.ID] {
+			continue
+		}
+		if search != "" && !strings.Contains(e.name, search) && !containsAny(e.members, search) {
+			continue
+		}
+		if a.CreationDate < c.MinCreation || a.CreationDate > maxCreation {
+			continue
+		}
+		if e.albumYear != 0 && (e.albumYear < c.MinAlbum || e.albumYear > maxAlbum) {
+			continue
+		}
+		if len(members) > 0 {
+			count := len(a.Members)
+			if !members[count] && !(count >= MembersMax && members[MembersMax]) {
+				continue
+			}
+		}
+		if location != "" && !containsAny(e.locations, location) {
+			continue
+		}
+		matched = append(matched, e)
+	}
+
+	sortEntries(matched, c.Sort)
+
+	result := make([]models.Artist, len(matched))
+	for i, e := range matched {
+		result[i] = e.artist
+	}
+	return result
+}
+
+// MemberMatch est un membre trouvé par la recherche, avec ses groupes
+type MemberMatch struct {
+	Name    string
+	Artists []models.Artist
+}
+
+// Members retourne les membres dont le nom contient search, triés par nom.
+// Un même nom (à la casse près) présent dans plusieurs groupes n'apparaît
+// qu'une fois.
+func (idx *Index) Members(search string) []MemberMatch {
+	search = strings.ToLower(strings.TrimSpace(search))
+	if search == "" {
+		return nil
+	}
+	byName := make(map[string]*MemberMatch)
+	var keys []string
+	for _, e := range idx.entries {
+		for i, m := range e.members {
+			if !strings.Contains(m, search) {
+				continue
+			}
+			match := byName[m]
+			if match == nil {
+				match = &MemberMatch{Name: strings.TrimSpace(e.artist.Members[i])}
+				byName[m] = match
+				keys = append(keys, m)
+			}
+			match.Artists = append(match.Artists, e.artist)
+		}
+	}
+	sort.Strings(keys)
+	result := make([]MemberMatch, len(keys))
+	for i, k := range keys {
+		result[i] = *byName[k]
+	}
+	return result
+}
+
+func containsAny(values []string, sub string) bool {
+	for _, v := range values {
+		if strings.Contains(v, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortEntries(list []*entry, key SortKey) {
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		switch key {
+		case SortNameDesc:
+			return a.name > b.name
+		case SortCreationOld:
+			return a.artist.CreationDate < b.artist.CreationDate
+		case SortCreationNew:
+			return a.artist.CreationDate > b.artist.CreationDate
+		case SortFirstAlbumOld:
+			return a.albumDate.Before(b.albumDate)
+		case SortFirstAlbumNew:
+			return a.albumDate.After(b.albumDate)
+		default:
+			return a.name < b.name
+		}
+	})
+}
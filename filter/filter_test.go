@@ -0,0 +1,120 @@
+package filter
+
+import (
+	"slices"
+	"testing"
+
+	"groupie-tracker/models"
+)
+
+var testArtists = []models.Artist{
+	{ID: 1, Name: "Queen", Members: []string{"Freddie Mercury", "Brian May", "Roger Taylor", "John Deacon"}, CreationDate: 1970, FirstAlbum: "14-12-1973"},
+	{ID: 2, Name: "Gorillaz", Members: []string{"Damon Albarn", "Jamie Hewlett"}, CreationDate: 1998, FirstAlbum: "26-03-2001"},
+	{ID: 3, Name: "Pink Floyd", Members: []string{"Roger Waters", "David Gilmour", "Nick Mason", "Richard Wright", "Syd Barrett"}, CreationDate: 1965, FirstAlbum: "05-08-1967"},
+	{ID: 4, Name: "Arctic Monkeys", Members: []string{"Alex Turner", "Jamie Cook", "Matt Helders", "Nick O'Malley", "a", "b", "c", "d", "e"}, CreationDate: 2002, FirstAlbum: "23-01-2006"},
+	{ID: 5, Name: "Sans album", Members: []string{"X"}, CreationDate: 2010, FirstAlbum: "inconnu"},
+}
+
+var testLocations = map[int][]string{
+	1: {"london-uk", "paris-france"},
+	2: {"north_carolina-usa"},
+	3: {"london-uk"},
+}
+
+func ids(artists []models.Artist) []int {
+	out := make([]int, len(artists))
+	for i, a := range artists {
+		out[i] = a.ID
+	}
+	return out
+}
+
+func TestApply(t *testing.T) {
+	idx := NewIndex(testArtists, testLocations)
+	favs := map[int]models.Favorite{
+		1: {ID: 1, Rating: 5, Tags: []string{"rock", "live"}},
+		2: {ID: 2, Rating: 2, Tags: []string{"pop"}},
+	}
+
+	tests := []struct {
+		name string
+		c    Criteria
+		want []int // triés par nom (tri par défaut)
+	}{
+		{"sans filtre", Criteria{}, []int{4, 2, 3, 1, 5}},
+		{"nom", Criteria{Search: "  QUE "}, []int{1}},
+		{"membre", Criteria{Search: "roger"}, []int{3, 1}},
+		{"création min", Criteria{MinCreation: 1998}, []int{4, 2, 5}},
+		{"création bornée", Criteria{MinCreation: 1966, MaxCreation: 1998}, []int{2, 1}},
+		{"album", Criteria{MinAlbum: 2000, MaxAlbum: 2005}, []int{2, 5}},
+		{"membres", Criteria{Members: []int{2, 5}}, []int{2, 3}},
+		{"8 membres et plus", Criteria{Members: []int{MembersMax}}, []int{4}},
+		{"lieu nettoyé", Criteria{Location: "north carolina"}, []int{2}},
+		{"pays", Criteria{Location: "UK"}, []int{3, 1}},
+		{"favoris", Criteria{FavoritesOnly: true}, []int{2, 1}},
+		{"tag", Criteria{Tag: "LIV"}, []int{1}},
+		{"note", Criteria{MinRating: 3}, []int{1}},
+		{"collection", Criteria{ArtistIDs: map[int]bool{3: true, 5: true}}, []int{3, 5}},
+		{"collection vide", Criteria{ArtistIDs: map[int]bool{}}, []int{}},
+		{"filtres combinés", Criteria{Search: "o", Location: "london", MaxCreation: 1968}, []int{3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ids(idx.Apply(tt.c, favs)); !slices.Equal(got, tt.want) {
+				t.Errorf("Apply = %v, attendu %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortKeys(t *testing.T) {
+	idx := NewIndex(testArtists, nil)
+	tests := []struct {
+		key  SortKey
+		want []int
+	}{
+		{SortNameAsc, []int{4, 2, 3, 1, 5}},
+		{SortNameDesc, []int{5, 1, 3, 2, 4}},
+		{SortCreationOld, []int{3, 1, 2, 4, 5}},
+		{SortCreationNew, []int{5, 4, 2, 1, 3}},
+		// Une date illisible compte comme la plus ancienne
+		{SortFirstAlbumOld, []int{5, 3, 1, 2, 4}},
+		{SortFirstAlbumNew, []int{4, 2, 1, 3, 5}},
+		{"inconnu", []int{4, 2, 3, 1, 5}},
+	}
+	if len(SortKeys) != len(tests)-1 {
+		t.Fatalf("%d tris disponibles, %d testés", len(SortKeys), len(tests)-1)
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.key), func(t *testing.T) {
+			if got := ids(idx.Apply(Criteria{Sort: tt.key}, nil)); !slices.Equal(got, tt.want) {
+				t.Errorf("tri %q = %v, attendu %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMembers(t *testing.T) {
+	artists := append(slices.Clone(testArtists), models.Artist{ID: 6, Name: "Blur", Members: []string{"damon albarn "}})
+	idx := NewIndex(artists, nil)
+
+	got := idx.Members("ALBARN")
+	if len(got) != 1 || got[0].Name != "Damon Albarn" || !slices.Equal(ids(got[0].Artists), []int{2, 6}) {
+		t.Errorf("Members = %+v", got)
+	}
+	if got := idx.Members("nick"); len(got) != 2 || got[0].Name != "Nick Mason" {
+		t.Errorf("Members = %+v", got)
+	}
+	if got := idx.Members(" "); got != nil {
+		t.Errorf("recherche vide = %+v", got)
+	}
+}
+
+func TestAlbumYear(t *testing.T) {
+	tests := map[string]int{"14-12-1973": 1973, "1999": 1999, "99": 0, "": 0, "inconnu": 0}
+	for in, want := range tests {
+		if got := AlbumYear(in); got != want {
+			t.Errorf("AlbumYear(%q) = %d, attendu %d", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+//go:build !nogui
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"groupie-tracker/api"
+	"groupie-tracker/ui"
+
+	"fyne.io/fyne/v2/app"
+)
+
+func runGUI(route string) {
+	if route != "" {
+		if _, _, err := ui.ParseRoute(route); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+
+	a := app.New()
+	ui.LoadPreferences(a)
+	w := a.NewWindow("Groupie Tracker")
+	w.SetMaster() // fermer la fenêtre principale ferme aussi les fiches ouvertes à part
+
+	artists, err := api.FetchArtists()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	content := ui.ArtistList(a, w, artists, route)
+	w.SetContent(content)
+	ui.RestoreWindow(w)
+	w.ShowAndRun()
+}
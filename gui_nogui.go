@@ -0,0 +1,16 @@
+//go:build nogui
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"groupie-tracker/cli"
+)
+
+// Binaire compilé avec -tags nogui (serveurs sans écran) : pas d'interface graphique
+func runGUI(string) {
+	fmt.Fprintln(os.Stderr, "interface graphique absente de ce binaire (compilé avec -tags nogui)")
+	os.Exit(cli.Run([]string{"help"}, os.Stdout, os.Stderr) + 2)
+}
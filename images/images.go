@@ -0,0 +1,292 @@
+// Package images est le service unique de chargement des images d'artistes :
+// cache mémoire LRU des miniatures, cache disque des originaux, déduplication
+// des requêtes simultanées et nombre de téléchargements parallèles limité.
+package images
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/image/draw"
+)
+
+// Tailles de miniatures utilisées par l'interface
+const (
+	SizeList   = 70
+	SizeGrid   = 120
+	SizeDetail = 220
+)
+
+const (
+	memEntries    = 512
+	maxDownloads  = 6
+	failureTTL    = 5 * time.Minute
+	maxImageBytes = 10 << 20
+	userAgent     = "GroupieTracker-StudentProject/2.0 (education)"
+)
+
+// ErrUnavailable est renvoyée tant qu'une image récemment en échec n'est pas retentée
+var ErrUnavailable = errors.New("image indisponible")
+
+// Service charge et met en cache les images
+type Service struct {
+	client   *http.Client
+	cacheDir string // vide = pas de cache disque
+	mem      *lru
+	slots    chan struct{}
+	now      func() time.Time // horloge des échecs, remplacée dans les tests
+
+	mu       sync.Mutex
+	inflight map[string]*call
+	failures map[string]time.Time
+}
+
+type call struct {
+	wg  sync.WaitGroup
+	val any
+	err error
+}
+
+// NewService crée un service ; cacheDir vide désactive le cache disque
+func NewService(cacheDir string) *Service {
+	if cacheDir != "" {
+		if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+			cacheDir = ""
+		}
+	}
+	return &Service{
+		client:   &http.Client{Timeout: 15 * time.Second},
+		cacheDir: cacheDir,
+		mem:      newLRU(memEntries),
+		slots:    make(chan struct{}, maxDownloads),
+		now:      time.Now,
+		inflight: make(map[string]*call),
+		failures: make(map[string]time.Time),
+	}
+}
+
+var (
+	defaultOnce    sync.Once
+	defaultService *Service
+)
+
+// Default retourne le service partagé, avec un cache disque dans le dossier de cache du système
+func Default() *Service {
+	defaultOnce.Do(func() {
+		dir := ""
+		if base, err := os.UserCacheDir(); err == nil {
+			dir = filepath.Join(base, "groupie-tracker", "images")
+		}
+		defaultService = NewService(dir)
+	})
+	return defaultService
+}
+
+// Thumbnail retourne l'image réduite pour tenir dans un carré size x size (service partagé)
+func Thumbnail(url string, size int) (image.Image, error) {
+	return Default().Thumbnail(url, size)
+}
+
+// Thumbnail retourne l'image réduite pour tenir dans un carré size x size
+func (s *Service) Thumbnail(url string, size int) (image.Image, error) {
+	if strings.TrimSpace(url) == "" {
+		return nil, ErrUnavailable
+	}
+	key := fmt.Sprintf("%d|%s", size, url)
+	if img, ok := s.mem.get(key); ok {
+		return img, nil
+	}
+
+	val, err := s.do("thumb|"+key, func() (any, error) {
+		data, err := s.original(url)
+		if err != nil {
+			return nil, err
+		}
+		src, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		thumb := resize(src, size)
+		s.mem.add(key, thumb)
+		return thumb, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val.(image.Image), nil
+}
+
+// original retourne les octets de l'image : fichier local, cache disque ou téléchargement
+func (s *Service) original(url string) ([]byte, error) {
+	val, err := s.do("src|"+url, func() (any, error) {
+		if path, ok := localPath(url); ok {
+			return os.ReadFile(path)
+		}
+
+		s.mu.Lock()
+		failedAt, failed := s.failures[url]
+		s.mu.Unlock()
+		if failed && s.now().Sub(failedAt) < failureTTL {
+			return nil, ErrUnavailable
+		}
+
+		cachePath := s.diskPath(url)
+		if cachePath != "" {
+			if data, err := os.ReadFile(cachePath); err == nil && checkImage(data) == nil {
+				return data, nil
+			}
+		}
+
+		data, err := s.download(url)
+		s.mu.Lock()
+		if err != nil {
+			s.failures[url] = s.now()
+		} else {
+			delete(s.failures, url)
+		}
+		s.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+
+		if cachePath != "" {
+			writeCacheFile(cachePath, data)
+		}
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val.([]byte), nil
+}
+
+func (s *Service) download(url string) ([]byte, error) {
+	s.slots <- struct{}{}
+	defer func() { <-s.slots }()
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("image: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxImageBytes {
+		return nil, fmt.Errorf("image: plus de %d octets", maxImageBytes)
+	}
+	// Une page d'erreur servie avec un statut 200 ne doit pas entrer dans le cache disque
+	if err := checkImage(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// checkImage vérifie que data commence par une image d'un format connu
+func checkImage(data []byte) error {
+	if _, _, err := image.DecodeConfig(bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("image: format non reconnu: %w", err)
+	}
+	return nil
+}
+
+// do exécute fn une seule fois par clé : les appels simultanés attendent le même résultat
+func (s *Service) do(key string, fn func() (any, error)) (any, error) {
+	s.mu.Lock()
+	if c, ok := s.inflight[key]; ok {
+		s.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err
+	}
+	c := &call{}
+	c.wg.Add(1)
+	s.inflight[key] = c
+	s.mu.Unlock()
+
+	c.val, c.err = fn()
+
+	s.mu.Lock()
+	delete(s.inflight, key)
+	s.mu.Unlock()
+	c.wg.Done()
+	return c.val, c.err
+}
+
+func (s *Service) diskPath(url string) string {
+	if s.cacheDir == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(s.cacheDir, hex.EncodeToString(sum[:]))
+}
+
+// writeCacheFile écrit via un fichier temporaire pour ne jamais laisser d'image tronquée
+func writeCacheFile(path string, data []byte) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil || os.Rename(tmp.Name(), path) != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// localPath reconnaît les images choisies sur le disque (groupes créés par l'utilisateur)
+func localPath(url string) (string, bool) {
+	if !strings.HasPrefix(url, "file://") && strings.Contains(url, "://") {
+		return "", false
+	}
+	path := strings.TrimPrefix(url, "file://")
+	// file:///C:/... sous Windows
+	if len(path) > 2 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return path, true
+}
+
+// resize réduit src pour tenir dans un carré size x size en gardant les proportions
+func resize(src image.Image, size int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if size <= 0 || (w <= size && h <= size) {
+		return src
+	}
+	if w >= h {
+		h = max(1, h*size/w)
+		w = size
+	} else {
+		w = max(1, w*size/h)
+		h = size
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Over, nil)
+	return dst
+}
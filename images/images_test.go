@@ -0,0 +1,209 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func pngBytes(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestLRU(t *testing.T) {
+	c := newLRU(2)
+	a, b, d := image.NewGray(image.Rect(0, 0, 1, 1)), image.NewGray(image.Rect(0, 0, 2, 2)), image.NewGray(image.Rect(0, 0, 3, 3))
+	c.add("a", a)
+	c.add("b", b)
+	c.get("a") // a devient la plus récente, b sera évincée
+	c.add("d", d)
+
+	if _, ok := c.get("b"); ok {
+		t.Error("b aurait dû être évincée")
+	}
+	for key, want := range map[string]image.Image{"a": a, "d": d} {
+		if got, ok := c.get(key); !ok || got != want {
+			t.Errorf("get(%q) = %v, %v", key, got, ok)
+		}
+	}
+
+	c.add("a", d) // mise à jour sans nouvelle entrée
+	if got, _ := c.get("a"); got != d || c.order.Len() != 2 {
+		t.Errorf("après mise à jour : %v, %d entrées", got, c.order.Len())
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	var hits atomic.Int32
+	data := pngBytes(t, 400, 200)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write(data)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	s := NewService(dir)
+	img, err := s.Thumbnail(srv.URL+"/a.png", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != 100 || b.Dy() != 50 {
+		t.Errorf("miniature %v, attendu 100x50", b)
+	}
+	// Une autre taille réutilise l'original déjà téléchargé
+	if _, err := s.Thumbnail(srv.URL+"/a.png", 50); err != nil || hits.Load() != 1 {
+		t.Errorf("%d téléchargements, erreur %v", hits.Load(), err)
+	}
+
+	// Un nouveau service relit le cache disque sans réseau
+	srv.Close()
+	if _, err := NewService(dir).Thumbnail(srv.URL+"/a.png", 100); err != nil {
+		t.Errorf("cache disque : %v", err)
+	}
+}
+
+func TestDedup(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	data := pngBytes(t, 10, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		w.Write(data)
+	}))
+	defer srv.Close()
+
+	s := NewService("")
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			if _, err := s.Thumbnail(srv.URL+"/a.png", SizeList); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	// Laisse les appels se rejoindre sur la requête en cours
+	for hits.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := hits.Load(); n != 1 {
+		t.Errorf("%d requêtes pour 10 appels simultanés, attendu 1", n)
+	}
+}
+
+func TestConcurrencyCap(t *testing.T) {
+	var active, peak atomic.Int32
+	release := make(chan struct{})
+	data := pngBytes(t, 10, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := active.Add(1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		<-release
+		active.Add(-1)
+		w.Write(data)
+	}))
+	defer srv.Close()
+
+	s := NewService("")
+	var wg sync.WaitGroup
+	for i := range 3 * maxDownloads {
+		wg.Go(func() {
+			if _, err := s.Thumbnail(fmt.Sprintf("%s/%d.png", srv.URL, i), SizeList); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	for active.Load() < maxDownloads {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := active.Load(); n != maxDownloads {
+		t.Errorf("%d téléchargements en parallèle, attendu %d", n, maxDownloads)
+	}
+	close(release)
+	wg.Wait()
+
+	if p := peak.Load(); p > maxDownloads {
+		t.Errorf("pic de %d téléchargements, limite %d", p, maxDownloads)
+	}
+}
+
+func TestFailureTTL(t *testing.T) {
+	var hits atomic.Int32
+	var ok atomic.Bool
+	data := pngBytes(t, 10, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if !ok.Load() {
+			http.Error(w, "indisponible", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(data)
+	}))
+	defer srv.Close()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := NewService("")
+	s.now = func() time.Time { return now }
+	url := srv.URL + "/a.png"
+
+	if _, err := s.Thumbnail(url, SizeList); err == nil || errors.Is(err, ErrUnavailable) {
+		t.Fatalf("premier échec = %v", err)
+	}
+	ok.Store(true)
+	now = now.Add(failureTTL - time.Second)
+	if _, err := s.Thumbnail(url, SizeList); !errors.Is(err, ErrUnavailable) || hits.Load() != 1 {
+		t.Errorf("pendant le délai : %v après %d requêtes", err, hits.Load())
+	}
+	now = now.Add(time.Second)
+	if _, err := s.Thumbnail(url, SizeList); err != nil || hits.Load() != 2 {
+		t.Errorf("après le délai : %v après %d requêtes", err, hits.Load())
+	}
+}
+
+// Les réponses qui ne sont pas des images complètes ne sont jamais mises en cache
+func TestRejectedDownloads(t *testing.T) {
+	tests := []struct {
+		name string
+		body []byte
+	}{
+		{"page HTML", []byte("<html>erreur</html>")},
+		{"trop volumineuse", append(pngBytes(t, 10, 10), make([]byte, maxImageBytes)...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write(tt.body)
+			}))
+			defer srv.Close()
+
+			dir := t.TempDir()
+			if _, err := NewService(dir).Thumbnail(srv.URL+"/a.png", SizeList); err == nil {
+				t.Error("aucune erreur")
+			}
+			if entries, _ := os.ReadDir(dir); len(entries) > 0 {
+				t.Errorf("fichiers en cache : %v", entries)
+			}
+		})
+	}
+}
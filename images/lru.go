@@ -0,0 +1,53 @@
+package images
+
+import (
+	"container/list"
+	"image"
+	"sync"
+)
+
+// lru est un cache mémoire des miniatures décodées, limité en nombre d'entrées
+type lru struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // plus récent en tête
+	items    map[string]*list.Element
+}
+
+type lruEntry struct {
+	key string
+	img image.Image
+}
+
+func newLRU(capacity int) *lru {
+	return &lru{capacity: capacity, order: list.New(), items: make(map[string]*list.Element)}
+}
+
+func (c *lru) get(key string) (image.Image, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruEntry).img, true
+}
+
+func (c *lru) add(key string, img image.Image) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*lruEntry).img = img
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, img: img})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
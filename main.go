@@ -1,26 +1,25 @@
 package main
 
 import (
-	"log"
+	"os"
 
 	"groupie-tracker/api"
-	"groupie-tracker/ui"
-
-	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/app"
+	"groupie-tracker/cli"
+	"groupie-tracker/store"
 )
 
 func main() {
-	a := app.New()
-	w := a.NewWindow("Groupie Tracker")
+	// Les lieux géocodés sont partagés par tous les modes via le dossier de données
+	api.SetGeocodeStore(store.Geocodes{})
 
-	artists, err := api.FetchArtists()
-	if err != nil {
-		log.Fatal(err)
+	// groupie-tracker list|search|show|concerts|export|tui|web|proxy : mode sans fenêtre
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
 	}
-
-	content := ui.ArtistList(a, w, artists)
-	w.SetContent(content)
-	w.Resize(fyne.NewSize(800, 600))
-	w.ShowAndRun()
+	// Sinon l'argument éventuel est une route : groupie-tracker artist/12
+	route := ""
+	if len(os.Args) > 1 {
+		route = os.Args[1]
+	}
+	runGUI(route)
 }
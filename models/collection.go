@@ -0,0 +1,8 @@
+package models
+
+// Collection est une liste nommée et ordonnée d'artistes (ex: "festival 2026", "à écouter").
+type Collection struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	ArtistIDs []int  `json:"artistIds"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// Concert est une date de concert d'un artiste dans un lieu.
+// Location garde la forme de l'API ("north_carolina-usa").
+type Concert struct {
+	ArtistID int       `json:"artistId"`
+	Location string    `json:"location"`
+	Date     time.Time `json:"date"`
+}
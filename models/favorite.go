@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Favorite regroupe les informations personnelles attachées à un artiste favori.
+type Favorite struct {
+	ID      int       `json:"id"`
+	Note    string    `json:"note,omitempty"`
+	Rating  int       `json:"rating,omitempty"` // 1 à 5 étoiles, 0 = pas de note
+	Tags    []string  `json:"tags,omitempty"`
+	AddedAt time.Time `json:"addedAt"`
+}
@@ -0,0 +1,7 @@
+package models
+
+// LocalBand est un groupe créé par l'utilisateur, avec ses concerts (ville -> dates).
+type LocalBand struct {
+	Artist         Artist              `json:"artist"`
+	DatesLocations map[string][]string `json:"datesLocations"`
+}
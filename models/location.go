@@ -4,3 +4,10 @@ type Location struct {
 	ID        int      `json:"id"`
 	Locations []string `json:"locations"`
 }
+
+// Coordinates est la position d'un lieu telle que la renvoie le géocodage
+// (latitude et longitude en texte)
+type Coordinates struct {
+	Lat string `json:"lat"`
+	Lon string `json:"lon"`
+}
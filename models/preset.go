@@ -0,0 +1,19 @@
+package models
+
+// FilterPreset mémorise une combinaison de filtres de la liste des artistes.
+// Les bornes sont gardées telles que saisies dans les champs.
+type FilterPreset struct {
+	Name          string   `json:"name"`
+	Search        string   `json:"search,omitempty"`
+	MinCreation   string   `json:"minCreation,omitempty"`
+	MaxCreation   string   `json:"maxCreation,omitempty"`
+	MinAlbum      string   `json:"minAlbum,omitempty"`
+	MaxAlbum      string   `json:"maxAlbum,omitempty"`
+	Members       []string `json:"members,omitempty"`
+	Location      string   `json:"location,omitempty"`
+	Tag           string   `json:"tag,omitempty"`
+	MinRating     int      `json:"minRating,omitempty"`
+	Collection    string   `json:"collection,omitempty"`
+	FavoritesOnly bool     `json:"favoritesOnly,omitempty"`
+	Sort          string   `json:"sort,omitempty"`
+}
@@ -0,0 +1,244 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"groupie-tracker/api"
+	"groupie-tracker/catalog"
+	"groupie-tracker/models"
+	"groupie-tracker/store"
+)
+
+const (
+	defaultPerPage = 20
+	maxPerPage     = 100
+	cacheMaxAge    = time.Minute
+)
+
+// artistSummary est un artiste de la liste
+type artistSummary struct {
+	ID           int      `json:"id"`
+	Name         string   `json:"name"`
+	Image        string   `json:"image"`
+	Members      []string `json:"members"`
+	CreationDate int      `json:"creationDate"`
+	FirstAlbum   string   `json:"firstAlbum"` // AAAA-MM-JJ
+	Local        bool     `json:"local,omitempty"`
+	ConcertCount int      `json:"concertCount"`
+	Locations    []string `json:"locations"`
+	Href         string   `json:"href"`
+}
+
+// artistDetail est la vue complète d'un artiste : une seule requête au lieu de quatre
+type artistDetail struct {
+	artistSummary
+	SpotifyLink string           `json:"spotifyLink,omitempty"`
+	YoutubeLink string           `json:"youtubeLink,omitempty"`
+	DeezerLink  string           `json:"deezerLink,omitempty"`
+	Favorite    *models.Favorite `json:"favorite,omitempty"`
+	Concerts    []concertJSON    `json:"concerts"`
+}
+
+// concertJSON est un concert typé ; les coordonnées ne sont présentes que si
+// le lieu a déjà été géocodé, par ce processus ou un autre mode de
+// l'application (cache sur disque) : le proxy n'interroge pas Nominatim
+type concertJSON struct {
+	Date      string   `json:"date"` // AAAA-MM-JJ
+	Location  string   `json:"location"`
+	City      string   `json:"city"`
+	Country   string   `json:"country"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+type artistPage struct {
+	Items      []artistSummary `json:"items"`
+	Page       int             `json:"page"`
+	PerPage    int             `json:"perPage"`
+	Total      int             `json:"total"`
+	TotalPages int             `json:"totalPages"`
+}
+
+func (s *Server) handleArtists(w http.ResponseWriter, r *http.Request) {
+	data, err := s.dataset()
+	if err != nil {
+		writeError(w, r, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	q := r.URL.Query()
+	crit, err := catalog.ParseQuery(q)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	page, err := intParam(q, "page", 1, 1, 0)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	perPage, err := intParam(q, "per_page", defaultPerPage, 1, maxPerPage)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	favs, _ := store.LoadFavoriteEntries()
+	artists := data.cat.Index().Apply(crit, favs)
+
+	total := len(artists)
+	totalPages := max((total+perPage-1)/perPage, 1)
+	from := min((page-1)*perPage, total)
+	to := min(from+perPage, total)
+
+	out := artistPage{
+		Items:      make([]artistSummary, 0, to-from),
+		Page:       page,
+		PerPage:    perPage,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+	for _, a := range artists[from:to] {
+		out.Items = append(out.Items, summary(data, a))
+	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	w.Header().Set("Link", pageLinks(r.URL, page, totalPages))
+	writeJSON(w, r, http.StatusOK, out, cacheMaxAge)
+}
+
+func (s *Server) handleArtist(w http.ResponseWriter, r *http.Request) {
+	data, err := s.dataset()
+	if err != nil {
+		writeError(w, r, http.StatusBadGateway, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "identifiant invalide")
+		return
+	}
+	artist, ok := data.cat.Find(strconv.Itoa(id))
+	if !ok {
+		writeError(w, r, http.StatusNotFound, "artiste introuvable")
+		return
+	}
+
+	out := artistDetail{
+		artistSummary: summary(data, artist),
+		SpotifyLink:   artist.SpotifyLink,
+		YoutubeLink:   artist.YoutubeLink,
+		DeezerLink:    artist.DeezerLink,
+		Concerts:      make([]concertJSON, 0, len(data.concerts[id])),
+	}
+	favs, _ := store.LoadFavoriteEntries()
+	if fav, ok := favs[id]; ok {
+		out.Favorite = &fav
+	}
+	for _, c := range data.concerts[id] {
+		out.Concerts = append(out.Concerts, typedConcert(c))
+	}
+	writeJSON(w, r, http.StatusOK, out, cacheMaxAge)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	data := s.data
+	s.mu.Unlock()
+
+	status := map[string]any{"status": "ok"}
+	if data != nil {
+		status["artists"] = len(data.cat.Artists)
+		status["loadedAt"] = data.loadedAt.UTC().Format(time.RFC3339)
+	} else {
+		status["status"] = "loading"
+	}
+	writeJSON(w, r, http.StatusOK, status, 0)
+}
+
+func summary(data *dataset, a models.Artist) artistSummary {
+	locations := data.cat.Locations[a.ID]
+	labels := make([]string, len(locations))
+	for i, l := range locations {
+		labels[i] = api.LocationLabel(l)
+	}
+	return artistSummary{
+		ID:           a.ID,
+		Name:         a.Name,
+		Image:        a.Image,
+		Members:      a.Members,
+		CreationDate: a.CreationDate,
+		FirstAlbum:   isoDate(a.FirstAlbum),
+		Local:        data.cat.IsLocal(a.ID),
+		ConcertCount: len(data.concerts[a.ID]),
+		Locations:    labels,
+		Href:         "/v1/artists/" + strconv.Itoa(a.ID),
+	}
+}
+
+func typedConcert(c models.Concert) concertJSON {
+	label := api.LocationLabel(c.Location)
+	out := concertJSON{
+		Date:     c.Date.Format("2006-01-02"),
+		Location: c.Location,
+		City:     label,
+	}
+	if i := strings.LastIndex(label, ", "); i >= 0 {
+		out.City, out.Country = label[:i], label[i+2:]
+	}
+	if lat, lon, ok := api.CachedCoordinates(label); ok {
+		la, errLat := strconv.ParseFloat(lat, 64)
+		lo, errLon := strconv.ParseFloat(lon, 64)
+		if errLat == nil && errLon == nil {
+			out.Latitude, out.Longitude = &la, &lo
+		}
+	}
+	return out
+}
+
+// isoDate convertit "jj-mm-aaaa" en "aaaa-mm-jj" (inchangé si illisible)
+func isoDate(date string) string {
+	t, err := time.Parse("02-01-2006", date)
+	if err != nil {
+		return date
+	}
+	return t.Format("2006-01-02")
+}
+
+// intParam lit un entier borné ; hi = 0 signifie sans borne haute
+func intParam(q url.Values, name string, def, lo, hi int) (int, error) {
+	v := q.Get(name)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < lo || (hi > 0 && n > hi) {
+		if hi > 0 {
+			return 0, fmt.Errorf("paramètre %s invalide : %q (entre %d et %d)", name, v, lo, hi)
+		}
+		return 0, fmt.Errorf("paramètre %s invalide : %q", name, v)
+	}
+	return n, nil
+}
+
+// pageLinks construit l'en-tête Link (RFC 8288) vers les pages voisines
+func pageLinks(u *url.URL, page, totalPages int) string {
+	link := func(p int, rel string) string {
+		q := u.Query()
+		q.Set("page", strconv.Itoa(p))
+		return fmt.Sprintf(`<%s?%s>; rel="%s"`, u.Path, q.Encode(), rel)
+	}
+	links := []string{link(1, "first"), link(totalPages, "last")}
+	if page > 1 {
+		links = append(links, link(min(page-1, totalPages), "prev"))
+	}
+	if page < totalPages {
+		links = append(links, link(page+1, "next"))
+	}
+	return strings.Join(links, ", ")
+}
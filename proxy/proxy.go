@@ -0,0 +1,187 @@
+// Package proxy est le serveur d'agrégation (groupie-tracker proxy) : il
+// réunit artistes, lieux, dates et relations de l'API en une seule réponse
+// par artiste, avec filtres, pagination, cache et ETags.
+package proxy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"groupie-tracker/api"
+	"groupie-tracker/catalog"
+	"groupie-tracker/models"
+)
+
+// DefaultAddr n'écoute que sur la machine locale
+const DefaultAddr = "127.0.0.1:8081"
+
+// DefaultTTL est la durée pendant laquelle les données de l'API sont réutilisées
+const DefaultTTL = 10 * time.Minute
+
+// dataset est une copie des données de l'API à un instant donné
+type dataset struct {
+	cat      *catalog.Catalog
+	concerts map[int][]models.Concert
+	loadedAt time.Time
+}
+
+// Server répond aux requêtes à partir des données en cache, rechargées
+// au plus une fois par TTL
+type Server struct {
+	ttl  time.Duration
+	load func() (*dataset, error)
+	mux  *http.ServeMux
+
+	mu      sync.Mutex
+	data    *dataset
+	loading chan struct{} // non nil pendant un rechargement
+}
+
+// NewServer crée un serveur ; les données sont chargées à la première requête
+func NewServer(ttl time.Duration) *Server {
+	return newServer(ttl, loadDataset)
+}
+
+func newServer(ttl time.Duration, load func() (*dataset, error)) *Server {
+	s := &Server{ttl: ttl, load: load, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /v1/artists", s.handleArtists)
+	s.mux.HandleFunc("GET /v1/artists/{id}", s.handleArtist)
+	s.mux.HandleFunc("GET /v1/health", s.handleHealth)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// ListenAndServe charge les données puis sert l'API sur addr
+func ListenAndServe(addr string, ttl time.Duration) error {
+	s := NewServer(ttl)
+	if _, err := s.dataset(); err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	log.Printf("Proxy Groupie Tracker disponible sur http://%s/v1/artists", ln.Addr())
+
+	srv := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return srv.Serve(ln)
+}
+
+func loadDataset() (*dataset, error) {
+	cat, err := catalog.Load(true)
+	if err != nil {
+		return nil, err
+	}
+	concerts, err := cat.AllConcerts()
+	if err != nil {
+		return nil, err
+	}
+	return &dataset{cat: cat, concerts: concerts, loadedAt: time.Now()}, nil
+}
+
+// dataset retourne les données en cache. Quand elles ont expiré, une seule
+// requête les recharge ; si l'API ne répond pas, les anciennes sont gardées.
+func (s *Server) dataset() (*dataset, error) {
+	s.mu.Lock()
+	data := s.data
+	if data != nil && time.Since(data.loadedAt) < s.ttl {
+		s.mu.Unlock()
+		return data, nil
+	}
+	if wait := s.loading; wait != nil {
+		s.mu.Unlock()
+		if data != nil {
+			return data, nil
+		}
+		<-wait
+		return s.dataset()
+	}
+	done := make(chan struct{})
+	s.loading = done
+	s.mu.Unlock()
+
+	fresh, err := s.load()
+	if err == nil {
+		// Les lieux géocodés entre-temps par les autres modes sont repris
+		if gerr := api.ReloadGeocodes(); gerr != nil {
+			log.Printf("proxy: cache de géocodage illisible : %v", gerr)
+		}
+	}
+
+	s.mu.Lock()
+	if err == nil {
+		s.data = fresh
+	}
+	s.loading = nil
+	s.mu.Unlock()
+	close(done)
+
+	if err != nil {
+		if data != nil {
+			log.Printf("proxy: rechargement impossible, anciennes données conservées : %v", err)
+			return data, nil
+		}
+		return nil, err
+	}
+	return fresh, nil
+}
+
+// writeJSON envoie v avec un ETag calculé sur le contenu ; répond 304 si le
+// client a déjà cette version
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, v any, maxAge time.Duration) {
+	body, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	body = append(body, '\n')
+
+	h := w.Header()
+	h.Set("Content-Type", "application/json; charset=utf-8")
+	if status == http.StatusOK {
+		sum := sha256.Sum256(body)
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		h.Set("ETag", etag)
+		h.Set("Cache-Control", "public, max-age="+strconv.Itoa(int(maxAge.Seconds())))
+		if etagMatch(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// etagMatch applique la comparaison faible de If-None-Match (RFC 9110)
+func etagMatch(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+type errorJSON struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, msg string) {
+	writeJSON(w, r, status, errorJSON{Error: msg}, 0)
+}
@@ -0,0 +1,244 @@
+package proxy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"groupie-tracker/catalog"
+	"groupie-tracker/api"
+	"groupie-tracker/models"
+	"groupie-tracker/store"
+)
+
+// TestMain prépare un dossier de données contenant un lieu déjà géocodé par
+// un autre mode de l'application
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "groupie-proxy")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Setenv("GROUPIE_TRACKER_HOME", dir)
+	api.SetGeocodeStore(store.Geocodes{})
+	if err := store.SaveGeocode("paris, france", models.Coordinates{Lat: "48.8566", Lon: "2.3522"}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// testDataset construit count artistes factices, sans passer par l'API
+func testDataset(count int) *dataset {
+	cat := &catalog.Catalog{Locations: make(map[int][]string)}
+	concerts := make(map[int][]models.Concert)
+	for i := 1; i <= count; i++ {
+		cat.Artists = append(cat.Artists, models.Artist{ID: i, Name: fmt.Sprintf("Groupe %02d", i), CreationDate: 1960 + i, FirstAlbum: "05-08-1967"})
+		cat.Locations[i] = []string{"paris-france", "lyon-france"}
+		concerts[i] = []models.Concert{
+			{ArtistID: i, Location: "paris-france", Date: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)},
+			{ArtistID: i, Location: "lyon-france", Date: time.Date(2020, 2, 3, 0, 0, 0, 0, time.UTC)},
+		}
+	}
+	return &dataset{cat: cat, concerts: concerts, loadedAt: time.Now()}
+}
+
+func get(t *testing.T, s *Server, target string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest("GET", target, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPagination(t *testing.T) {
+	s := newServer(time.Hour, func() (*dataset, error) { return testDataset(45), nil })
+
+	tests := []struct {
+		query     string
+		status    int
+		items     int
+		firstID   int
+		links     []string
+		noLinkFor string
+	}{
+		{"", http.StatusOK, 20, 1, []string{`page=2>; rel="next"`, `page=3>; rel="last"`}, "prev"},
+		{"?page=2", http.StatusOK, 20, 21, []string{`page=1>; rel="prev"`, `page=3>; rel="next"`}, ""},
+		{"?page=3", http.StatusOK, 5, 41, []string{`page=2>; rel="prev"`}, "next"},
+		{"?page=9", http.StatusOK, 0, 0, []string{`page=3>; rel="prev"`}, "next"},
+		{"?per_page=100&min_creation=2000", http.StatusOK, 6, 40, []string{`min_creation=2000&page=1&per_page=100>; rel="first"`}, "next"},
+		{"?per_page=101", http.StatusBadRequest, 0, 0, nil, ""},
+		{"?page=0", http.StatusBadRequest, 0, 0, nil, ""},
+		{"?sort=inconnu", http.StatusBadRequest, 0, 0, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec := get(t, s, "/v1/artists"+tt.query, nil)
+			if rec.Code != tt.status {
+				t.Fatalf("statut %d, attendu %d : %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			var page artistPage
+			if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+				t.Fatal(err)
+			}
+			if len(page.Items) != tt.items || (tt.items > 0 && page.Items[0].ID != tt.firstID) {
+				t.Errorf("%d éléments à partir de %+v", len(page.Items), page.Items)
+			}
+			if rec.Header().Get("X-Total-Count") != fmt.Sprint(page.Total) {
+				t.Errorf("X-Total-Count = %q, total %d", rec.Header().Get("X-Total-Count"), page.Total)
+			}
+			link := rec.Header().Get("Link")
+			for _, want := range tt.links {
+				if !strings.Contains(link, want) {
+					t.Errorf("Link = %s ; attendu %s", link, want)
+				}
+			}
+			if tt.noLinkFor != "" && strings.Contains(link, `rel="`+tt.noLinkFor+`"`) {
+				t.Errorf("Link = %s ; %s inattendu", link, tt.noLinkFor)
+			}
+		})
+	}
+}
+
+func TestETag(t *testing.T) {
+	s := newServer(time.Hour, func() (*dataset, error) { return testDataset(3), nil })
+
+	first := get(t, s, "/v1/artists/1", nil)
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Header().Get("Cache-Control") != "public, max-age=60" {
+		t.Fatalf("statut %d, en-têtes %v", first.Code, first.Header())
+	}
+	if other := get(t, s, "/v1/artists/2", nil).Header().Get("ETag"); other == etag {
+		t.Error("même ETag pour deux artistes différents")
+	}
+
+	tests := []struct {
+		name, ifNoneMatch string
+		status            int
+	}{
+		{"identique", etag, http.StatusNotModified},
+		{"faible", "W/" + etag, http.StatusNotModified},
+		{"liste", `"autre", ` + etag, http.StatusNotModified},
+		{"joker", "*", http.StatusNotModified},
+		{"différent", `"autre"`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := get(t, s, "/v1/artists/1", http.Header{"If-None-Match": {tt.ifNoneMatch}})
+			if rec.Code != tt.status {
+				t.Errorf("statut %d, attendu %d", rec.Code, tt.status)
+			}
+			if tt.status == http.StatusNotModified && rec.Body.Len() > 0 {
+				t.Errorf("corps envoyé avec 304 : %s", rec.Body)
+			}
+		})
+	}
+
+	// Les erreurs ne portent pas d'ETag
+	if rec := get(t, s, "/v1/artists/99", nil); rec.Code != http.StatusNotFound || rec.Header().Get("ETag") != "" {
+		t.Errorf("statut %d, ETag %q", rec.Code, rec.Header().Get("ETag"))
+	}
+}
+
+func TestArtistDetail(t *testing.T) {
+	s := newServer(time.Hour, func() (*dataset, error) { return testDataset(3), nil })
+
+	rec := get(t, s, "/v1/artists/2", nil)
+	var out artistDetail
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.FirstAlbum != "1967-08-05" || out.ConcertCount != 2 || out.Locations[1] != "Lyon, France" {
+		t.Errorf("fiche = %+v", out)
+	}
+	paris, lyon := out.Concerts[0], out.Concerts[1]
+	// Paris vient du cache de géocodage enregistré sur disque, Lyon n'y est pas
+	if paris.City != "Paris" || paris.Country != "France" || paris.Latitude == nil || *paris.Latitude != 48.8566 || *paris.Longitude != 2.3522 {
+		t.Errorf("concert à Paris = %+v", paris)
+	}
+	if lyon.Latitude != nil || lyon.Longitude != nil {
+		t.Errorf("coordonnées inventées pour Lyon : %+v", lyon)
+	}
+}
+
+// Un lieu géocodé par un autre mode après le démarrage du proxy apparaît au
+// rechargement suivant des données
+func TestGeocodesReload(t *testing.T) {
+	s := newServer(0, func() (*dataset, error) { return testDataset(1), nil })
+	coords := func() *float64 {
+		var out artistDetail
+		if err := json.Unmarshal(get(t, s, "/v1/artists/1", nil).Body.Bytes(), &out); err != nil {
+			t.Fatal(err)
+		}
+		return out.Concerts[1].Latitude
+	}
+
+	if lat := coords(); lat != nil {
+		t.Fatalf("Lyon déjà géocodé : %v", *lat)
+	}
+	if err := store.SaveGeocode("lyon, france", models.Coordinates{Lat: "45.7578", Lon: "4.8320"}); err != nil {
+		t.Fatal(err)
+	}
+	if lat := coords(); lat == nil || *lat != 45.7578 {
+		t.Errorf("Lyon après rechargement : %v", lat)
+	}
+}
+
+func TestDatasetTTL(t *testing.T) {
+	var calls atomic.Int32
+	fail := atomic.Bool{}
+	s := newServer(0, func() (*dataset, error) {
+		calls.Add(1)
+		if fail.Load() {
+			return nil, errors.New("API indisponible")
+		}
+		return testDataset(2), nil
+	})
+
+	if rec := get(t, s, "/v1/health", nil); !strings.Contains(rec.Body.String(), `"loading"`) {
+		t.Errorf("health avant chargement = %s", rec.Body)
+	}
+	get(t, s, "/v1/artists", nil)
+	get(t, s, "/v1/artists", nil)
+	if n := calls.Load(); n != 2 {
+		t.Errorf("%d chargements avec un TTL nul, attendu 2", n)
+	}
+
+	// Si l'API ne répond plus, les anciennes données sont servies
+	fail.Store(true)
+	if rec := get(t, s, "/v1/artists/1", nil); rec.Code != http.StatusOK {
+		t.Errorf("statut %d après un rechargement échoué", rec.Code)
+	}
+
+	cached := newServer(time.Hour, func() (*dataset, error) {
+		calls.Add(1)
+		return testDataset(2), nil
+	})
+	calls.Store(0)
+	for range 3 {
+		get(t, cached, "/v1/artists", nil)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("%d chargements pendant le TTL, attendu 1", n)
+	}
+
+	down := newServer(time.Hour, func() (*dataset, error) { return nil, errors.New("API indisponible") })
+	if rec := get(t, down, "/v1/artists", nil); rec.Code != http.StatusBadGateway {
+		t.Errorf("statut %d sans données, attendu 502", rec.Code)
+	}
+}
@@ -0,0 +1,33 @@
+package stats
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"groupie-tracker/models"
+)
+
+// Event est un concert rattaché à son artiste
+type Event struct {
+	ArtistID int            `json:"artistId"`
+	Concert  models.Concert `json:"concert"`
+}
+
+// ByDay regroupe les concerts de tous les artistes par jour (date à minuit
+// UTC), dans l'ordre des ID d'artistes pour un même jour
+func ByDay(concerts map[int][]models.Concert) map[time.Time][]Event {
+	out := make(map[time.Time][]Event)
+	for id, list := range concerts {
+		for _, c := range list {
+			day := time.Date(c.Date.Year(), c.Date.Month(), c.Date.Day(), 0, 0, 0, 0, time.UTC)
+			out[day] = append(out[day], Event{ArtistID: id, Concert: c})
+		}
+	}
+	for _, events := range out {
+		slices.SortFunc(events, func(a, b Event) int {
+			return cmp.Or(cmp.Compare(a.ArtistID, b.ArtistID), cmp.Compare(a.Concert.Location, b.Concert.Location))
+		})
+	}
+	return out
+}
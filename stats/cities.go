@@ -0,0 +1,101 @@
+package stats
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"groupie-tracker/api"
+	"groupie-tracker/models"
+)
+
+// City regroupe les concerts donnés dans une ville, tous artistes confondus
+type City struct {
+	Location  string    `json:"location"` // lieu brut de l'API, ex. "paris-france"
+	Name      string    `json:"name"`
+	Country   string    `json:"country"`
+	Concerts  int       `json:"concerts"`
+	ArtistIDs []int     `json:"artistIds"`
+	First     time.Time `json:"first"`
+	Last      time.Time `json:"last"`
+}
+
+// CitySort est un ordre de la liste des villes
+type CitySort string
+
+const (
+	CityByConcerts CitySort = "concerts" // les plus visitées d'abord
+	CityByDate     CitySort = "date"     // concert le plus récent d'abord
+	CityByName     CitySort = "name"
+)
+
+// CitySorts liste les ordres dans l'ordre du sélecteur
+var CitySorts = []CitySort{CityByConcerts, CityByDate, CityByName}
+
+// Cities liste toutes les villes : les lieux de chaque artiste (locations)
+// donnent les artistes, les concerts datés (relations) le nombre de concerts
+// et les dates. Les ID d'artistes suivent l'ordre croissant.
+func Cities(locations map[int][]string, concerts map[int][]models.Concert) []City {
+	byLabel := make(map[string]*City)
+	artists := make(map[string]map[int]bool)
+	get := func(loc string) *City {
+		label := api.LocationLabel(loc)
+		c := byLabel[label]
+		if c == nil {
+			country := Country(loc)
+			c = &City{Location: loc, Name: strings.TrimSuffix(label, ", "+country), Country: country}
+			byLabel[label] = c
+			artists[label] = make(map[int]bool)
+		}
+		return c
+	}
+	add := func(loc string, id int) *City {
+		c := get(loc)
+		artists[api.LocationLabel(loc)][id] = true
+		return c
+	}
+
+	for id, locs := range locations {
+		for _, loc := range locs {
+			add(loc, id)
+		}
+	}
+	for id, list := range concerts {
+		for _, con := range list {
+			c := add(con.Location, id)
+			c.Concerts++
+			if c.First.IsZero() || con.Date.Before(c.First) {
+				c.First = con.Date
+			}
+			if con.Date.After(c.Last) {
+				c.Last = con.Date
+			}
+		}
+	}
+
+	out := make([]City, 0, len(byLabel))
+	for label, c := range byLabel {
+		for id := range artists[label] {
+			c.ArtistIDs = append(c.ArtistIDs, id)
+		}
+		slices.Sort(c.ArtistIDs)
+		out = append(out, *c)
+	}
+	SortCities(out, CityByConcerts)
+	return out
+}
+
+// SortCities trie les villes selon by, puis par nom
+func SortCities(cities []City, by CitySort) {
+	slices.SortFunc(cities, func(a, b City) int {
+		var c int
+		switch by {
+		case CityByConcerts:
+			c = cmp.Or(cmp.Compare(b.Concerts, a.Concerts), cmp.Compare(len(b.ArtistIDs), len(a.ArtistIDs)))
+		case CityByDate:
+			c = b.Last.Compare(a.Last)
+		}
+		return cmp.Or(c, cmp.Compare(a.Name, b.Name), cmp.Compare(a.Country, b.Country))
+	})
+}
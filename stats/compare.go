@@ -0,0 +1,127 @@
+package stats
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"groupie-tracker/api"
+	"groupie-tracker/models"
+)
+
+// TourGap est l'écart maximal entre deux concerts d'une même tournée
+const TourGap = 60 * 24 * time.Hour
+
+// CityShare est une ville où plusieurs des artistes comparés ont joué
+type CityShare struct {
+	City      string `json:"city"`
+	ArtistIDs []int  `json:"artistIds"`
+}
+
+// Period est une tournée : des concerts successifs espacés de moins de TourGap
+type Period struct {
+	ArtistID int       `json:"artistId"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Concerts int       `json:"concerts"`
+}
+
+// Overlap est la période pendant laquelle deux artistes étaient en tournée
+type Overlap struct {
+	A, B       Period
+	Start, End time.Time
+}
+
+// SharedCities retourne les villes communes à au moins deux artistes, les plus
+// partagées d'abord. Les ID d'artistes suivent l'ordre croissant.
+func SharedCities(concerts map[int][]models.Concert) []CityShare {
+	byCity := make(map[string]map[int]bool)
+	for id, list := range concerts {
+		for _, c := range list {
+			city := api.LocationLabel(c.Location)
+			if byCity[city] == nil {
+				byCity[city] = make(map[int]bool)
+			}
+			byCity[city][id] = true
+		}
+	}
+
+	var out []CityShare
+	for city, ids := range byCity {
+		if len(ids) < 2 {
+			continue
+		}
+		share := CityShare{City: city}
+		for id := range ids {
+			share.ArtistIDs = append(share.ArtistIDs, id)
+		}
+		slices.Sort(share.ArtistIDs)
+		out = append(out, share)
+	}
+	slices.SortFunc(out, func(a, b CityShare) int {
+		if c := cmp.Compare(len(b.ArtistIDs), len(a.ArtistIDs)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.City, b.City)
+	})
+	return out
+}
+
+// Tours regroupe les concerts (triés par date) d'un artiste en tournées
+func Tours(artistID int, concerts []models.Concert, gap time.Duration) []Period {
+	var out []Period
+	for _, c := range concerts {
+		if n := len(out); n > 0 && c.Date.Sub(out[n-1].End) <= gap {
+			out[n-1].End = c.Date
+			out[n-1].Concerts++
+			continue
+		}
+		out = append(out, Period{ArtistID: artistID, Start: c.Date, End: c.Date, Concerts: 1})
+	}
+	return out
+}
+
+// OverlappingTours retourne, pour chaque paire d'artistes, les tournées qui
+// se chevauchent, dans l'ordre chronologique
+func OverlappingTours(concerts map[int][]models.Concert, gap time.Duration) []Overlap {
+	ids := make([]int, 0, len(concerts))
+	for id := range concerts {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	tours := make(map[int][]Period, len(ids))
+	for _, id := range ids {
+		tours[id] = Tours(id, concerts[id], gap)
+	}
+
+	var out []Overlap
+	for i, a := range ids {
+		for _, b := range ids[i+1:] {
+			for _, pa := range tours[a] {
+				for _, pb := range tours[b] {
+					start := maxTime(pa.Start, pb.Start)
+					end := minTime(pa.End, pb.End)
+					if !end.Before(start) {
+						out = append(out, Overlap{A: pa, B: pb, Start: start, End: end})
+					}
+				}
+			}
+		}
+	}
+	slices.SortFunc(out, func(x, y Overlap) int { return x.Start.Compare(y.Start) })
+	return out
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,72 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"groupie-tracker/models"
+)
+
+func day(id int, location string, year int, month time.Month, d int) models.Concert {
+	return models.Concert{ArtistID: id, Location: location, Date: time.Date(year, month, d, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestTours(t *testing.T) {
+	concerts := []models.Concert{
+		day(1, "london-uk", 2019, time.January, 1),
+		day(1, "paris-france", 2019, time.February, 1),
+		day(1, "berlin-germany", 2019, time.April, 2), // 60 jours pile : même tournée
+		day(1, "tokyo-japan", 2019, time.June, 2),     // 61 jours : nouvelle tournée
+	}
+	want := []Period{
+		{ArtistID: 1, Start: concerts[0].Date, End: concerts[2].Date, Concerts: 3},
+		{ArtistID: 1, Start: concerts[3].Date, End: concerts[3].Date, Concerts: 1},
+	}
+	if got := Tours(1, concerts, TourGap); !reflect.DeepEqual(got, want) {
+		t.Errorf("Tours = %+v\nattendu %+v", got, want)
+	}
+	if got := Tours(1, concerts, 0); len(got) != len(concerts) {
+		t.Errorf("écart nul : %d tournées, attendu %d", len(got), len(concerts))
+	}
+	if got := Tours(1, nil, TourGap); got != nil {
+		t.Errorf("sans concerts : %+v", got)
+	}
+}
+
+func TestOverlappingTours(t *testing.T) {
+	concerts := map[int][]models.Concert{
+		2: {day(2, "lyon-france", 2019, time.January, 15), day(2, "rome-italy", 2019, time.March, 1)},
+		1: {day(1, "london-uk", 2019, time.January, 1), day(1, "paris-france", 2019, time.February, 1)},
+		3: {day(3, "oslo-norway", 2010, time.May, 1)},
+	}
+	got := OverlappingTours(concerts, TourGap)
+	if len(got) != 1 {
+		t.Fatalf("%d chevauchements, attendu 1 : %+v", len(got), got)
+	}
+	o := got[0]
+	if o.A.ArtistID != 1 || o.B.ArtistID != 2 {
+		t.Errorf("paire %d-%d, attendu 1-2", o.A.ArtistID, o.B.ArtistID)
+	}
+	if !o.Start.Equal(concerts[2][0].Date) || !o.End.Equal(concerts[1][1].Date) {
+		t.Errorf("période %v - %v", o.Start, o.End)
+	}
+}
+
+func TestSharedCities(t *testing.T) {
+	concerts := map[int][]models.Concert{
+		1: {concert(1, "london-uk", 1975), concert(1, "paris-france", 1975), concert(1, "london-uk", 1980)},
+		2: {concert(2, "paris-france", 1990), concert(2, "london-uk", 1990)},
+		3: {concert(3, "london-uk", 2019), concert(3, "berlin-germany", 2019)},
+	}
+	want := []CityShare{
+		{City: "London, UK", ArtistIDs: []int{1, 2, 3}},
+		{City: "Paris, France", ArtistIDs: []int{1, 2}},
+	}
+	if got := SharedCities(concerts); !reflect.DeepEqual(got, want) {
+		t.Errorf("SharedCities = %+v\nattendu %+v", got, want)
+	}
+	if got := SharedCities(testConcerts); !reflect.DeepEqual(got, []CityShare{{City: "London, UK", ArtistIDs: []int{1, 3}}}) {
+		t.Errorf("SharedCities(testConcerts) = %+v", got)
+	}
+}
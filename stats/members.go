@@ -0,0 +1,74 @@
+package stats
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"groupie-tracker/models"
+)
+
+// Link relie deux artistes qui partagent au moins un membre (A < B)
+type Link struct {
+	A       int      `json:"a"`
+	B       int      `json:"b"`
+	Members []string `json:"members"`
+}
+
+// MemberKey normalise un nom de membre : casse et espaces ignorés
+func MemberKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// BandsOf retourne les artistes dont name fait partie, dans l'ordre de artists
+func BandsOf(name string, artists []models.Artist) []models.Artist {
+	key := MemberKey(name)
+	var out []models.Artist
+	for _, a := range artists {
+		if slices.ContainsFunc(a.Members, func(m string) bool { return MemberKey(m) == key }) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// SharedMembers retourne les paires d'artistes ayant des membres en commun,
+// triées par ID
+func SharedMembers(artists []models.Artist) []Link {
+	// Pour chaque membre : son nom affiché et les artistes où il apparaît
+	names := make(map[string]string)
+	bands := make(map[string][]int)
+	for _, a := range artists {
+		for _, m := range a.Members {
+			key := MemberKey(m)
+			if key == "" || slices.Contains(bands[key], a.ID) {
+				continue
+			}
+			names[key] = strings.TrimSpace(m)
+			bands[key] = append(bands[key], a.ID)
+		}
+	}
+
+	byPair := make(map[[2]int]*Link)
+	for key, ids := range bands {
+		for i, a := range ids {
+			for _, b := range ids[i+1:] {
+				pair := [2]int{min(a, b), max(a, b)}
+				if byPair[pair] == nil {
+					byPair[pair] = &Link{A: pair[0], B: pair[1]}
+				}
+				byPair[pair].Members = append(byPair[pair].Members, names[key])
+			}
+		}
+	}
+
+	out := make([]Link, 0, len(byPair))
+	for _, l := range byPair {
+		slices.Sort(l.Members)
+		out = append(out, *l)
+	}
+	slices.SortFunc(out, func(x, y Link) int {
+		return cmp.Or(cmp.Compare(x.A, y.A), cmp.Compare(x.B, y.B))
+	})
+	return out
+}
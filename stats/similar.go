@@ -0,0 +1,162 @@
+package stats
+
+import (
+	"cmp"
+	"slices"
+
+	"groupie-tracker/api"
+	"groupie-tracker/models"
+)
+
+// Poids des critères de similarité (leur somme vaut 1)
+const (
+	weightEra     = 0.3
+	weightMembers = 0.2
+	weightCities  = 0.3
+	weightTours   = 0.2
+)
+
+// Écarts au-delà desquels l'époque ou l'effectif ne rapprochent plus deux artistes
+const (
+	eraSpan     = 20
+	membersSpan = 4
+)
+
+// Match est un artiste et sa similarité (entre 0 et 1) avec l'artiste de référence
+type Match struct {
+	Artist models.Artist `json:"artist"`
+	Score  float64       `json:"score"`
+}
+
+// Suggestion est un artiste recommandé à cause d'un favori proche
+type Suggestion struct {
+	Match
+	Because models.Artist `json:"because"`
+}
+
+// Similarity note la ressemblance de deux artistes entre 0 et 1 : époque de
+// création, nombre de membres, villes de concert communes et tournées
+// simultanées. Sans concerts, seuls les deux premiers critères comptent.
+func Similarity(a, b models.Artist, concertsA, concertsB []models.Concert) float64 {
+	era := closeness(a.CreationDate-b.CreationDate, eraSpan)
+	members := closeness(len(a.Members)-len(b.Members), membersSpan)
+	return weightEra*era + weightMembers*members +
+		weightCities*citiesOverlap(concertsA, concertsB) +
+		weightTours*toursOverlap(a.ID, b.ID, concertsA, concertsB)
+}
+
+// closeness vaut 1 pour un écart nul et décroît jusqu'à 0 à span
+func closeness(diff, span int) float64 {
+	if diff < 0 {
+		diff = -diff
+	}
+	return max(0, 1-float64(diff)/float64(span))
+}
+
+// citiesOverlap est l'indice de Jaccard des villes de concert
+func citiesOverlap(a, b []models.Concert) float64 {
+	cities := func(list []models.Concert) map[string]bool {
+		set := make(map[string]bool, len(list))
+		for _, c := range list {
+			set[api.LocationLabel(c.Location)] = true
+		}
+		return set
+	}
+	ca, cb := cities(a), cities(b)
+	common := 0
+	for city := range ca {
+		if cb[city] {
+			common++
+		}
+	}
+	if union := len(ca) + len(cb) - common; union > 0 {
+		return float64(common) / float64(union)
+	}
+	return 0
+}
+
+// toursOverlap est la part des tournées des deux artistes qui croisent une
+// tournée de l'autre
+func toursOverlap(idA, idB int, a, b []models.Concert) float64 {
+	toursA, toursB := Tours(idA, a, TourGap), Tours(idB, b, TourGap)
+	if len(toursA)+len(toursB) == 0 {
+		return 0
+	}
+	crossing := func(p Period, others []Period) bool {
+		return slices.ContainsFunc(others, func(o Period) bool {
+			return !p.Start.After(o.End) && !o.Start.After(p.End)
+		})
+	}
+	n := 0
+	for _, p := range toursA {
+		if crossing(p, toursB) {
+			n++
+		}
+	}
+	for _, p := range toursB {
+		if crossing(p, toursA) {
+			n++
+		}
+	}
+	return float64(n) / float64(len(toursA)+len(toursB))
+}
+
+// Similar retourne les limit artistes les plus proches de target, du plus
+// similaire au moins similaire ; limit <= 0 les garde tous. concerts peut
+// valoir nil.
+func Similar(target models.Artist, artists []models.Artist, concerts map[int][]models.Concert, limit int) []Match {
+	out := make([]Match, 0, len(artists))
+	for _, a := range artists {
+		if a.ID == target.ID {
+			continue
+		}
+		out = append(out, Match{Artist: a, Score: Similarity(target, a, concerts[target.ID], concerts[a.ID])})
+	}
+	sortMatches(out, func(m Match) Match { return m })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// Recommend propose des artistes absents des favoris, chacun rattaché au
+// favori dont il est le plus proche, les meilleures suggestions d'abord ;
+// limit <= 0 les garde toutes
+func Recommend(artists []models.Artist, favorites map[int]bool, concerts map[int][]models.Concert, limit int) []Suggestion {
+	best := make(map[int]Suggestion)
+	for _, fav := range artists {
+		if !favorites[fav.ID] {
+			continue
+		}
+		for _, a := range artists {
+			if favorites[a.ID] {
+				continue
+			}
+			score := Similarity(fav, a, concerts[fav.ID], concerts[a.ID])
+			if cur, ok := best[a.ID]; !ok || score > cur.Score {
+				best[a.ID] = Suggestion{Match: Match{Artist: a, Score: score}, Because: fav}
+			}
+		}
+	}
+
+	out := make([]Suggestion, 0, len(best))
+	for _, s := range best {
+		out = append(out, s)
+	}
+	sortMatches(out, func(s Suggestion) Match { return s.Match })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// sortMatches trie par score décroissant puis par nom
+func sortMatches[T any](list []T, match func(T) Match) {
+	slices.SortFunc(list, func(x, y T) int {
+		a, b := match(x), match(y)
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Artist.Name, b.Artist.Name)
+	})
+}
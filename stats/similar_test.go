@@ -0,0 +1,127 @@
+package stats
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"groupie-tracker/models"
+)
+
+func TestSimilarity(t *testing.T) {
+	tour := func(id int) []models.Concert {
+		return []models.Concert{concert(id, "london-uk", 1975), concert(id, "paris-france", 1975)}
+	}
+	tests := []struct {
+		name         string
+		a, b         models.Artist
+		concA, concB []models.Concert
+		want         float64
+	}{
+		{"identiques", artist(1, 1970, "", 4), artist(2, 1970, "", 4), tour(1), tour(2), 1},
+		{"sans concerts", artist(1, 1970, "", 4), artist(2, 1970, "", 4), nil, nil, weightEra + weightMembers},
+		{"écarts partiels", artist(1, 1970, "", 4), artist(2, 1980, "", 2), nil, nil, weightEra/2 + weightMembers/2},
+		{"éloignés", artist(1, 1960, "", 1), artist(2, 2000, "", 8), tour(1), []models.Concert{concert(2, "tokyo-japan", 2010)}, 0},
+		{"une ville sur deux, même tournée", artist(1, 1970, "", 4), artist(2, 1970, "", 4), tour(1), tour(2)[:1], weightEra + weightMembers + weightCities/2 + weightTours},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Similarity(tt.a, tt.b, tt.concA, tt.concB)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("= %v, attendu %v", got, tt.want)
+			}
+			if back := Similarity(tt.b, tt.a, tt.concB, tt.concA); math.Abs(back-got) > 1e-9 {
+				t.Errorf("non symétrique : %v et %v", got, back)
+			}
+		})
+	}
+}
+
+// Deux favoris (1 et 3) et deux candidats à égalité, chacun proche d'un favori
+func recommendArtists() []models.Artist {
+	zappa := artist(2, 1972, "", 4)
+	zappa.Name = "Zappa"
+	return []models.Artist{
+		artist(1, 1970, "", 4),
+		zappa,
+		artist(3, 2000, "", 2),
+		artist(4, 1998, "", 2),
+	}
+}
+
+func TestRecommend(t *testing.T) {
+	artists := recommendArtists()
+	favorites := map[int]bool{1: true, 2: false, 3: true}
+
+	got := Recommend(artists, favorites, nil, 0)
+	if len(got) != 2 {
+		t.Fatalf("%d suggestions, attendu 2 : %+v", len(got), got)
+	}
+	// Même score : l'ordre suit le nom, pas l'ID
+	want := []struct{ id, because int }{{4, 3}, {2, 1}}
+	for i, w := range want {
+		if got[i].Artist.ID != w.id || got[i].Because.ID != w.because {
+			t.Errorf("suggestion %d : artiste %d à cause de %d, attendu %d à cause de %d",
+				i, got[i].Artist.ID, got[i].Because.ID, w.id, w.because)
+		}
+	}
+	if got[0].Score != got[1].Score {
+		t.Errorf("scores %v et %v, attendu une égalité", got[0].Score, got[1].Score)
+	}
+
+	for limit, n := range map[int]int{-1: 2, 0: 2, 1: 1, 5: 2} {
+		if got := Recommend(artists, favorites, nil, limit); len(got) != n {
+			t.Errorf("limit %d : %d suggestions, attendu %d", limit, len(got), n)
+		}
+	}
+	if got := Recommend(artists, nil, nil, 0); len(got) != 0 {
+		t.Errorf("sans favoris : %+v", got)
+	}
+}
+
+func TestSimilar(t *testing.T) {
+	artists := recommendArtists()
+	concerts := map[int][]models.Concert{
+		1: {concert(1, "london-uk", 1975)},
+		4: {concert(4, "london-uk", 1975)},
+	}
+
+	got := Similar(artists[0], artists, concerts, 0)
+	ids := []int{}
+	for _, m := range got {
+		ids = append(ids, m.Artist.ID)
+	}
+	// 4 est loin par l'époque mais partage la ville et la tournée de 1
+	if want := []int{4, 2, 3}; !slices.Equal(ids, want) {
+		t.Errorf("IDs = %v, attendu %v", ids, want)
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i].Score > got[i-1].Score {
+			t.Errorf("scores non décroissants : %+v", got)
+		}
+	}
+
+	for limit, n := range map[int]int{-3: 3, 0: 3, 2: 2, 10: 3} {
+		if got := Similar(artists[0], artists, concerts, limit); len(got) != n {
+			t.Errorf("limit %d : %d artistes, attendu %d", limit, len(got), n)
+		}
+	}
+}
+
+func TestCloseness(t *testing.T) {
+	tests := []struct {
+		diff, span int
+		want       float64
+	}{
+		{0, 20, 1},
+		{5, 20, 0.75},
+		{-5, 20, 0.75},
+		{20, 20, 0},
+		{40, 20, 0},
+	}
+	for _, tt := range tests {
+		if got := closeness(tt.diff, tt.span); got != tt.want {
+			t.Errorf("closeness(%d, %d) = %v, attendu %v", tt.diff, tt.span, got, tt.want)
+		}
+	}
+}
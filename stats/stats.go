@@ -0,0 +1,240 @@
+// Package stats calcule des agrégats sur les artistes et leurs concerts
+// (répartitions, classements, part des favoris) pour le tableau de bord.
+// Les fonctions ne font aucune requête : les concerts sont fournis par l'appelant.
+package stats
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"groupie-tracker/api"
+	"groupie-tracker/filter"
+	"groupie-tracker/models"
+)
+
+// Bucket est une barre d'histogramme
+type Bucket struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// Pair compare, pour une même catégorie, le catalogue et les favoris
+type Pair struct {
+	Label     string `json:"label"`
+	Catalog   int    `json:"catalog"`
+	Favorites int    `json:"favorites"`
+}
+
+// Summary rassemble les chiffres clés
+type Summary struct {
+	Artists     int     `json:"artists"`
+	Favorites   int     `json:"favorites"`
+	Concerts    int     `json:"concerts"`
+	Cities      int     `json:"cities"`
+	Countries   int     `json:"countries"`
+	AvgMembers  float64 `json:"avgMembers"`
+	AvgAlbumGap float64 `json:"avgAlbumGap"` // années entre création et premier album
+}
+
+// decade retourne "1970s" pour 1975 ("" si l'année est inconnue)
+func decade(year int) string {
+	if year <= 0 {
+		return ""
+	}
+	return strconv.Itoa(year/10*10) + "s"
+}
+
+// ByCreationDecade compte les groupes par décennie de création, dans l'ordre chronologique
+func ByCreationDecade(artists []models.Artist) []Bucket {
+	counts := make(map[string]int)
+	for _, a := range artists {
+		if d := decade(a.CreationDate); d != "" {
+			counts[d]++
+		}
+	}
+	return sortedByLabel(counts)
+}
+
+// MemberCounts compte les groupes par nombre de membres, de 1 à filter.MembersMax ("8+")
+func MemberCounts(artists []models.Artist) []Bucket {
+	out := make([]Bucket, filter.MembersMax)
+	for i := range out {
+		out[i].Label = strconv.Itoa(i + 1)
+	}
+	out[len(out)-1].Label += "+"
+	for _, a := range artists {
+		if n := len(a.Members); n > 0 {
+			out[min(n, filter.MembersMax)-1].Count++
+		}
+	}
+	return out
+}
+
+// albumGapRanges découpe l'écart entre création et premier album
+var albumGapRanges = []struct {
+	label    string
+	from, to int
+}{
+	{"0", 0, 0},
+	{"1", 1, 1},
+	{"2", 2, 2},
+	{"3-5", 3, 5},
+	{"6-10", 6, 10},
+	{"11+", 11, 1 << 30},
+}
+
+// albumGap retourne le nombre d'années entre création et premier album
+func albumGap(a models.Artist) (int, bool) {
+	year := filter.AlbumYear(a.FirstAlbum)
+	if year == 0 || a.CreationDate == 0 || year < a.CreationDate {
+		return 0, false
+	}
+	return year - a.CreationDate, true
+}
+
+// AlbumGap répartit les groupes selon le nombre d'années écoulées entre leur
+// création et leur premier album. Les dates incohérentes sont ignorées.
+func AlbumGap(artists []models.Artist) []Bucket {
+	out := make([]Bucket, len(albumGapRanges))
+	for i, r := range albumGapRanges {
+		out[i].Label = r.label
+	}
+	for _, a := range artists {
+		gap, ok := albumGap(a)
+		if !ok {
+			continue
+		}
+		for i, r := range albumGapRanges {
+			if gap >= r.from && gap <= r.to {
+				out[i].Count++
+				break
+			}
+		}
+	}
+	return out
+}
+
+// Country retourne le pays d'un lieu de l'API : "north_carolina-usa" -> "USA"
+func Country(location string) string {
+	label := api.LocationLabel(location)
+	if i := strings.LastIndex(label, ", "); i >= 0 {
+		return label[i+2:]
+	}
+	return label
+}
+
+// ConcertsByCountry classe les pays par nombre de concerts ; limit <= 0 garde tout
+func ConcertsByCountry(concerts map[int][]models.Concert, limit int) []Bucket {
+	counts := make(map[string]int)
+	for _, list := range concerts {
+		for _, c := range list {
+			counts[Country(c.Location)]++
+		}
+	}
+	return top(counts, limit)
+}
+
+// TopCities classe les villes par nombre de concerts ; limit <= 0 garde tout
+func TopCities(concerts map[int][]models.Concert, limit int) []Bucket {
+	counts := make(map[string]int)
+	for _, list := range concerts {
+		for _, c := range list {
+			counts[api.LocationLabel(c.Location)]++
+		}
+	}
+	return top(counts, limit)
+}
+
+// ConcertsByYear compte les concerts par année, dans l'ordre chronologique
+func ConcertsByYear(concerts map[int][]models.Concert) []Bucket {
+	counts := make(map[string]int)
+	for _, list := range concerts {
+		for _, c := range list {
+			counts[strconv.Itoa(c.Date.Year())]++
+		}
+	}
+	return sortedByLabel(counts)
+}
+
+// FavoritesByDecade compare, par décennie de création, le catalogue et les favoris
+func FavoritesByDecade(artists []models.Artist, favs map[int]models.Favorite) []Pair {
+	byLabel := make(map[string]*Pair)
+	var out []Pair
+	for _, b := range ByCreationDecade(artists) {
+		out = append(out, Pair{Label: b.Label, Catalog: b.Count})
+	}
+	for i := range out {
+		byLabel[out[i].Label] = &out[i]
+	}
+	for _, a := range artists {
+		if _, ok := favs[a.ID]; !ok {
+			continue
+		}
+		if p := byLabel[decade(a.CreationDate)]; p != nil {
+			p.Favorites++
+		}
+	}
+	return out
+}
+
+// Summarize calcule les chiffres clés
+func Summarize(artists []models.Artist, concerts map[int][]models.Concert, favs map[int]models.Favorite) Summary {
+	s := Summary{Artists: len(artists)}
+	members, gaps, gapCount := 0, 0, 0
+	cities := make(map[string]bool)
+	countries := make(map[string]bool)
+	for _, a := range artists {
+		if _, ok := favs[a.ID]; ok {
+			s.Favorites++
+		}
+		members += len(a.Members)
+		if gap, ok := albumGap(a); ok {
+			gaps += gap
+			gapCount++
+		}
+		for _, c := range concerts[a.ID] {
+			s.Concerts++
+			cities[api.LocationLabel(c.Location)] = true
+			countries[Country(c.Location)] = true
+		}
+	}
+	s.Cities, s.Countries = len(cities), len(countries)
+	if len(artists) > 0 {
+		s.AvgMembers = float64(members) / float64(len(artists))
+	}
+	if gapCount > 0 {
+		s.AvgAlbumGap = float64(gaps) / float64(gapCount)
+	}
+	return s
+}
+
+// top trie par effectif décroissant (puis par libellé) et garde les limit premiers
+func top(counts map[string]int, limit int) []Bucket {
+	out := make([]Bucket, 0, len(counts))
+	for label, n := range counts {
+		out = append(out, Bucket{Label: label, Count: n})
+	}
+	slices.SortFunc(out, func(a, b Bucket) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Label, b.Label)
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// sortedByLabel trie par libellé ; les libellés sont des années ou des
+// décennies de même longueur, l'ordre alphabétique est donc chronologique
+func sortedByLabel(counts map[string]int) []Bucket {
+	out := make([]Bucket, 0, len(counts))
+	for label, n := range counts {
+		out = append(out, Bucket{Label: label, Count: n})
+	}
+	slices.SortFunc(out, func(a, b Bucket) int { return cmp.Compare(a.Label, b.Label) })
+	return out
+}
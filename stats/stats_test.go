@@ -0,0 +1,183 @@
+package stats
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"groupie-tracker/models"
+)
+
+func artist(id int, creation int, firstAlbum string, members int) models.Artist {
+	a := models.Artist{ID: id, Name: fmt.Sprintf("Groupe %d", id), CreationDate: creation, FirstAlbum: firstAlbum}
+	for i := range members {
+		a.Members = append(a.Members, fmt.Sprintf("Membre %d", i))
+	}
+	return a
+}
+
+func concert(id int, location string, year int) models.Concert {
+	return models.Concert{ArtistID: id, Location: location, Date: time.Date(year, 6, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+var (
+	testArtists = []models.Artist{
+		artist(1, 1970, "14-12-1973", 4),
+		artist(2, 1975, "01-01-1975", 1),
+		artist(3, 1998, "26-03-2010", 12),
+		artist(4, 2005, "01-01-2001", 2), // premier album avant la création : ignoré
+		artist(5, 0, "", 0),
+	}
+	testConcerts = map[int][]models.Concert{
+		1: {concert(1, "london-uk", 1975), concert(1, "paris-france", 1975), concert(1, "london-uk", 1980)},
+		2: {concert(2, "north_carolina-usa", 1980)},
+		3: {concert(3, "los_angeles-usa", 2019), concert(3, "london-uk", 2019)},
+	}
+	testFavs = map[int]models.Favorite{1: {ID: 1}, 3: {ID: 3}, 99: {ID: 99}}
+)
+
+func TestBuckets(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []Bucket
+		want []Bucket
+	}{
+		{"décennies", ByCreationDecade(testArtists), []Bucket{{"1970s", 2}, {"1990s", 1}, {"2000s", 1}}},
+		{"membres", MemberCounts(testArtists), []Bucket{{"1", 1}, {"2", 1}, {"3", 0}, {"4", 1}, {"5", 0}, {"6", 0}, {"7", 0}, {"8+", 1}}},
+		{"premier album", AlbumGap(testArtists), []Bucket{{"0", 1}, {"1", 0}, {"2", 0}, {"3-5", 1}, {"6-10", 0}, {"11+", 1}}},
+		{"pays", ConcertsByCountry(testConcerts, 0), []Bucket{{"UK", 3}, {"USA", 2}, {"France", 1}}},
+		{"pays limités", ConcertsByCountry(testConcerts, 2), []Bucket{{"UK", 3}, {"USA", 2}}},
+		{"villes", TopCities(testConcerts, 3), []Bucket{{"London, UK", 3}, {"Los Angeles, USA", 1}, {"North Carolina, USA", 1}}},
+		{"années", ConcertsByYear(testConcerts), []Bucket{{"1975", 2}, {"1980", 2}, {"2019", 2}}},
+		{"vide", ConcertsByYear(nil), []Bucket{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.Equal(tt.got, tt.want) {
+				t.Errorf("= %v, attendu %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountry(t *testing.T) {
+	tests := map[string]string{
+		"north_carolina-usa": "USA",
+		"paris-france":       "France",
+		"london-uk":          "UK",
+		"playa_del_carmen":   "Playa Del Carmen",
+	}
+	for in, want := range tests {
+		if got := Country(in); got != want {
+			t.Errorf("Country(%q) = %q, attendu %q", in, got, want)
+		}
+	}
+}
+
+func TestFavoritesByDecade(t *testing.T) {
+	want := []Pair{{"1970s", 2, 1}, {"1990s", 1, 1}, {"2000s", 1, 0}}
+	if got := FavoritesByDecade(testArtists, testFavs); !slices.Equal(got, want) {
+		t.Errorf("FavoritesByDecade = %v, attendu %v", got, want)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	want := Summary{
+		Artists:     5,
+		Favorites:   2, // le favori 99 n'est pas dans la liste
+		Concerts:    6,
+		Cities:      4,
+		Countries:   3,
+		AvgMembers:  19.0 / 5,
+		AvgAlbumGap: (3 + 0 + 12) / 3.0,
+	}
+	if got := Summarize(testArtists, testConcerts, testFavs); got != want {
+		t.Errorf("Summarize = %+v\nattendu %+v", got, want)
+	}
+	if got := Summarize(nil, nil, nil); got != (Summary{}) {
+		t.Errorf("Summarize(nil) = %+v", got)
+	}
+}
+
+func TestCities(t *testing.T) {
+	locations := map[int][]string{1: {"london-uk", "paris-france"}, 2: {"london-uk"}, 3: {"berlin-germany"}}
+	concerts := map[int][]models.Concert{
+		1: {concert(1, "london-uk", 1975), concert(1, "paris-france", 1975), concert(1, "london-uk", 1980)},
+	}
+	year := func(y int) time.Time { return time.Date(y, 6, 1, 0, 0, 0, 0, time.UTC) }
+	london := City{Location: "london-uk", Name: "London", Country: "UK", Concerts: 2, ArtistIDs: []int{1, 2}, First: year(1975), Last: year(1980)}
+	paris := City{Location: "paris-france", Name: "Paris", Country: "France", Concerts: 1, ArtistIDs: []int{1}, First: year(1975), Last: year(1975)}
+	berlin := City{Location: "berlin-germany", Name: "Berlin", Country: "Germany", ArtistIDs: []int{3}}
+
+	cities := Cities(locations, concerts)
+	if want := []City{london, paris, berlin}; !reflect.DeepEqual(cities, want) {
+		t.Errorf("Cities = %+v\nattendu %+v", cities, want)
+	}
+	tests := map[CitySort][]City{
+		CityByDate: {london, paris, berlin},
+		CityByName: {berlin, london, paris},
+	}
+	for by, want := range tests {
+		SortCities(cities, by)
+		if !reflect.DeepEqual(cities, want) {
+			t.Errorf("SortCities(%s) = %+v", by, cities)
+		}
+	}
+}
+
+func TestMembers(t *testing.T) {
+	if got := MemberKey("  Freddie   MERCURY "); got != "freddie mercury" {
+		t.Errorf("MemberKey = %q", got)
+	}
+
+	band := func(id int, members ...string) models.Artist {
+		return models.Artist{ID: id, Name: fmt.Sprintf("Groupe %d", id), Members: members}
+	}
+	artists := []models.Artist{
+		band(3, "Roger Taylor", " Brian May "),
+		band(1, "brian may", "Roger Taylor", "roger taylor"),
+		band(2, "Brian May", "Paul Rodgers"),
+		band(4, "Paul Rodgers", ""),
+	}
+
+	var ids []int
+	for _, a := range BandsOf("BRIAN may", artists) {
+		ids = append(ids, a.ID)
+	}
+	if want := []int{3, 1, 2}; !slices.Equal(ids, want) {
+		t.Errorf("BandsOf = %v, attendu %v", ids, want)
+	}
+
+	// Un membre cité deux fois dans un groupe ne crée pas de lien en double ;
+	// le nom affiché est celui de la dernière fiche, sans les espaces autour
+	want := []Link{
+		{A: 1, B: 2, Members: []string{"Brian May"}},
+		{A: 1, B: 3, Members: []string{"Brian May", "Roger Taylor"}},
+		{A: 2, B: 3, Members: []string{"Brian May"}},
+		{A: 2, B: 4, Members: []string{"Paul Rodgers"}},
+	}
+	if got := SharedMembers(artists); !reflect.DeepEqual(got, want) {
+		t.Errorf("SharedMembers = %+v\nattendu %+v", got, want)
+	}
+}
+
+func TestByDay(t *testing.T) {
+	at := func(id int, location string, d, hour int) models.Concert {
+		return models.Concert{ArtistID: id, Location: location, Date: time.Date(2019, 6, d, hour, 0, 0, 0, time.UTC)}
+	}
+	concerts := map[int][]models.Concert{
+		2: {at(2, "paris-france", 1, 20)},
+		1: {at(1, "london-uk", 1, 18), at(1, "berlin-germany", 2, 0)},
+	}
+	got := ByDay(concerts)
+	first, second := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 6, 2, 0, 0, 0, 0, time.UTC)
+	want := map[time.Time][]Event{
+		first:  {{ArtistID: 1, Concert: concerts[1][0]}, {ArtistID: 2, Concert: concerts[2][0]}},
+		second: {{ArtistID: 1, Concert: concerts[1][1]}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ByDay = %+v\nattendu %+v", got, want)
+	}
+}
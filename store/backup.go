@@ -0,0 +1,470 @@
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"groupie-tracker/models"
+)
+
+// BackupVersion est la version actuelle du format de sauvegarde.
+// Version 0 = anciens exports (liste d'IDs, liste de favoris ou {favorites, collections}).
+const BackupVersion = 1
+
+const backupFormat = "groupie-tracker-backup"
+
+var (
+	ErrBackupFormat   = errors.New("sauvegarde: fichier non reconnu")
+	ErrBackupChecksum = errors.New("sauvegarde: somme de contrôle invalide, le fichier a été modifié ou tronqué")
+	ErrBackupVersion  = errors.New("sauvegarde: version non prise en charge")
+)
+
+// ImportMode choisit entre fusion avec les données actuelles et remplacement complet
+type ImportMode int
+
+const (
+	ImportMerge ImportMode = iota
+	ImportReplace
+)
+
+// BackupData est le contenu d'une sauvegarde
+type BackupData struct {
+	Favorites   []models.Favorite     `json:"favorites"`
+	Collections []models.Collection   `json:"collections"`
+	LocalBands  []models.LocalBand    `json:"localBands"`
+	Presets     []models.FilterPreset `json:"presets"`
+	Preferences *Preferences          `json:"preferences,omitempty"`
+}
+
+// Backup est le document écrit sur disque
+type Backup struct {
+	Format    string          `json:"format"`
+	Version   int             `json:"version"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Checksum  string          `json:"checksum"` // "sha256:<hex>" du champ data (JSON compact)
+	Data      json.RawMessage `json:"data"`
+}
+
+// CurrentBackupData rassemble toutes les données utilisateur actuelles
+func CurrentBackupData() (BackupData, error) {
+	var d BackupData
+
+	favs, err := LoadFavoriteEntries()
+	if err != nil {
+		return d, err
+	}
+	d.Favorites = SortedFavorites(favs)
+	if d.Collections, err = LoadCollections(); err != nil {
+		return d, err
+	}
+	if d.LocalBands, err = LoadLocalBands(); err != nil {
+		return d, err
+	}
+	if d.Presets, err = LoadPresets(); err != nil {
+		return d, err
+	}
+	prefs, err := LoadPreferences()
+	if err != nil {
+		return d, err
+	}
+	d.Preferences = &prefs
+	return d, nil
+}
+
+// WriteBackup écrit une sauvegarde versionnée et signée par somme de contrôle
+func WriteBackup(w io.Writer, data BackupData) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	doc := Backup{
+		Format:    backupFormat,
+		Version:   BackupVersion,
+		CreatedAt: time.Now(),
+		Checksum:  checksum(payload),
+		Data:      payload,
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// ReadBackup lit une sauvegarde et vérifie sa version et sa somme de contrôle.
+// Les anciens exports des paramètres sont convertis.
+func ReadBackup(raw []byte) (BackupData, error) {
+	var d BackupData
+	trimmed := bytes.TrimSpace(raw)
+
+	if !bytes.HasPrefix(trimmed, []byte("{")) {
+		favs, err := DecodeFavorites(trimmed)
+		if err != nil {
+			return d, ErrBackupFormat
+		}
+		d.Favorites = SortedFavorites(favs)
+		return d, nil
+	}
+
+	var doc Backup
+	if err := json.Unmarshal(trimmed, &doc); err != nil {
+		return d, fmt.Errorf("%w: %v", ErrBackupFormat, err)
+	}
+
+	if doc.Format == "" {
+		// Export {favorites, collections} des versions précédentes
+		var legacy struct {
+			Favorites   json.RawMessage     `json:"favorites"`
+			Collections []models.Collection `json:"collections"`
+		}
+		if err := json.Unmarshal(trimmed, &legacy); err != nil || legacy.Favorites == nil {
+			return d, ErrBackupFormat
+		}
+		favs, err := DecodeFavorites(legacy.Favorites)
+		if err != nil {
+			return d, fmt.Errorf("%w: %v", ErrBackupFormat, err)
+		}
+		d.Favorites = SortedFavorites(favs)
+		d.Collections = legacy.Collections
+		return d, nil
+	}
+
+	if doc.Format != backupFormat {
+		return d, ErrBackupFormat
+	}
+	if doc.Version < 1 || doc.Version > BackupVersion {
+		return d, fmt.Errorf("%w: %d (version maximale %d)", ErrBackupVersion, doc.Version, BackupVersion)
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, doc.Data); err != nil {
+		return d, fmt.Errorf("%w: %v", ErrBackupFormat, err)
+	}
+	if doc.Checksum != checksum(compact.Bytes()) {
+		return d, ErrBackupChecksum
+	}
+	if err := json.Unmarshal(compact.Bytes(), &d); err != nil {
+		return d, fmt.Errorf("%w: %v", ErrBackupFormat, err)
+	}
+	return d, nil
+}
+
+func checksum(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// UnknownIDsError liste les artistes référencés par une sauvegarde mais absents du catalogue
+type UnknownIDsError struct {
+	Favorites   []int
+	Collections map[string][]int
+}
+
+func (e *UnknownIDsError) Error() string {
+	var parts []string
+	if len(e.Favorites) > 0 {
+		parts = append(parts, "favoris "+joinIDs(e.Favorites))
+	}
+	names := make([]string, 0, len(e.Collections))
+	for name := range e.Collections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("collection \"%s\" %s", name, joinIDs(e.Collections[name])))
+	}
+	return "sauvegarde: artistes inconnus : " + strings.Join(parts, " ; ")
+}
+
+func joinIDs(ids []int) string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = fmt.Sprintf("#%d", id)
+	}
+	return strings.Join(strs, ", ")
+}
+
+// Validate vérifie que chaque artiste référencé existe dans known
+// ou parmi les groupes locaux de la sauvegarde.
+func (d BackupData) Validate(known map[int]bool) error {
+	ids := make(map[int]bool, len(known)+len(d.LocalBands))
+	for id, ok := range known {
+		ids[id] = ok
+	}
+	for _, b := range d.LocalBands {
+		ids[b.Artist.ID] = true
+	}
+
+	unknown := &UnknownIDsError{Collections: make(map[string][]int)}
+	for _, f := range d.Favorites {
+		if !ids[f.ID] {
+			unknown.Favorites = append(unknown.Favorites, f.ID)
+		}
+	}
+	for _, c := range d.Collections {
+		for _, id := range c.ArtistIDs {
+			if !ids[id] {
+				unknown.Collections[c.Name] = append(unknown.Collections[c.Name], id)
+			}
+		}
+	}
+	if len(unknown.Favorites) > 0 || len(unknown.Collections) > 0 {
+		return unknown
+	}
+	return nil
+}
+
+// ImportPlan décrit l'effet d'un import avant de l'appliquer (aperçu)
+type ImportPlan struct {
+	Mode ImportMode
+
+	FavoritesAdded, FavoritesUpdated, FavoritesRemoved       []int
+	CollectionsAdded, CollectionsUpdated, CollectionsRemoved []string
+	LocalBandsAdded, LocalBandsUpdated, LocalBandsRemoved    []string
+	PresetsAdded, PresetsUpdated, PresetsRemoved             []string
+	PreferencesChanged                                       bool
+
+	result BackupData
+}
+
+// Empty indique que l'import ne changerait rien
+func (p ImportPlan) Empty() bool {
+	return len(p.FavoritesAdded)+len(p.FavoritesUpdated)+len(p.FavoritesRemoved)+
+		len(p.CollectionsAdded)+len(p.CollectionsUpdated)+len(p.CollectionsRemoved)+
+		len(p.LocalBandsAdded)+len(p.LocalBandsUpdated)+len(p.LocalBandsRemoved)+
+		len(p.PresetsAdded)+len(p.PresetsUpdated)+len(p.PresetsRemoved) == 0 && !p.PreferencesChanged
+}
+
+// PlanImport calcule l'état obtenu en important incoming dans current.
+// En fusion, les éléments importés remplacent ceux de même ID / même nom et
+// les préférences actuelles sont conservées ; en remplacement, tout est repris
+// de la sauvegarde. catalog contient les IDs des artistes de l'API, que les
+// groupes importés ne doivent pas reprendre.
+func PlanImport(current, incoming BackupData, mode ImportMode, catalog map[int]bool) ImportPlan {
+	plan := ImportPlan{Mode: mode}
+	if mode == ImportMerge {
+		incoming = remapLocalBands(current.LocalBands, incoming, catalog)
+	} else {
+		incoming = remapLocalBands(nil, incoming, catalog)
+	}
+
+	// Favoris
+	curFavs := make(map[int]models.Favorite)
+	for _, f := range current.Favorites {
+		curFavs[f.ID] = f
+	}
+	newFavs := make(map[int]models.Favorite)
+	if mode == ImportMerge {
+		for id, f := range curFavs {
+			newFavs[id] = f
+		}
+	}
+	for _, f := range incoming.Favorites {
+		if old, ok := curFavs[f.ID]; ok && f.AddedAt.IsZero() {
+			f.AddedAt = old.AddedAt
+		}
+		newFavs[f.ID] = f
+	}
+	for id, f := range newFavs {
+		old, existed := curFavs[id]
+		switch {
+		case !existed:
+			plan.FavoritesAdded = append(plan.FavoritesAdded, id)
+		case !reflect.DeepEqual(old, f):
+			plan.FavoritesUpdated = append(plan.FavoritesUpdated, id)
+		}
+	}
+	for id := range curFavs {
+		if _, ok := newFavs[id]; !ok {
+			plan.FavoritesRemoved = append(plan.FavoritesRemoved, id)
+		}
+	}
+	sort.Ints(plan.FavoritesAdded)
+	sort.Ints(plan.FavoritesUpdated)
+	sort.Ints(plan.FavoritesRemoved)
+	plan.result.Favorites = SortedFavorites(newFavs)
+
+	// Collections (identifiées par leur nom)
+	if mode == ImportMerge {
+		plan.result.Collections = MergeCollections(current.Collections, incoming.Collections)
+	} else {
+		plan.result.Collections = append([]models.Collection(nil), incoming.Collections...)
+	}
+	plan.CollectionsAdded, plan.CollectionsUpdated, plan.CollectionsRemoved = diffNamed(
+		current.Collections, plan.result.Collections,
+		func(c models.Collection) string { return c.Name },
+		func(a, b models.Collection) bool { return reflect.DeepEqual(a.ArtistIDs, b.ArtistIDs) },
+	)
+
+	// Groupes locaux (identifiés par leur ID)
+	bands := make(map[int]models.LocalBand)
+	if mode == ImportMerge {
+		for _, b := range current.LocalBands {
+			bands[b.Artist.ID] = b
+		}
+	}
+	for _, b := range incoming.LocalBands {
+		bands[b.Artist.ID] = b
+	}
+	for _, b := range bands {
+		plan.result.LocalBands = append(plan.result.LocalBands, b)
+	}
+	sort.Slice(plan.result.LocalBands, func(i, j int) bool {
+		return plan.result.LocalBands[i].Artist.ID < plan.result.LocalBands[j].Artist.ID
+	})
+	plan.LocalBandsAdded, plan.LocalBandsUpdated, plan.LocalBandsRemoved = diffNamed(
+		current.LocalBands, plan.result.LocalBands,
+		func(b models.LocalBand) string { return fmt.Sprintf("#%d %s", b.Artist.ID, b.Artist.Name) },
+		func(a, b models.LocalBand) bool { return reflect.DeepEqual(a, b) },
+	)
+
+	// Préréglages (identifiés par leur nom)
+	if mode == ImportMerge {
+		plan.result.Presets = append([]models.FilterPreset(nil), current.Presets...)
+	}
+	for _, p := range incoming.Presets {
+		plan.result.Presets = UpsertPreset(plan.result.Presets, p)
+	}
+	plan.PresetsAdded, plan.PresetsUpdated, plan.PresetsRemoved = diffNamed(
+		current.Presets, plan.result.Presets,
+		func(p models.FilterPreset) string { return p.Name },
+		func(a, b models.FilterPreset) bool { return reflect.DeepEqual(a, b) },
+	)
+
+	// Préférences : uniquement en remplacement
+	plan.result.Preferences = current.Preferences
+	if mode == ImportReplace && incoming.Preferences != nil {
+		plan.result.Preferences = incoming.Preferences
+		plan.PreferencesChanged = current.Preferences == nil || *current.Preferences != *incoming.Preferences
+	}
+
+	return plan
+}
+
+// diffNamed compare deux listes d'éléments identifiés par une clé texte
+func diffNamed[T any](before, after []T, key func(T) string, same func(a, b T) bool) (added, updated, removed []string) {
+	old := make(map[string]T)
+	for _, item := range before {
+		old[strings.ToLower(key(item))] = item
+	}
+	seen := make(map[string]bool)
+	for _, item := range after {
+		k := strings.ToLower(key(item))
+		seen[k] = true
+		prev, ok := old[k]
+		if !ok {
+			added = append(added, key(item))
+		} else if !same(prev, item) {
+			updated = append(updated, key(item))
+		}
+	}
+	for _, item := range before {
+		if !seen[strings.ToLower(key(item))] {
+			removed = append(removed, key(item))
+		}
+	}
+	return added, updated, removed
+}
+
+// remapLocalBands donne un nouvel ID aux groupes importés qui entrent en
+// conflit avec un artiste de l'API ou un groupe local différent (current), et
+// met à jour les favoris et collections importés. Les nouveaux IDs suivent le
+// plus grand ID connu : API, groupes locaux et groupes importés.
+func remapLocalBands(current []models.LocalBand, incoming BackupData, catalog map[int]bool) BackupData {
+	curBands := make(map[int]models.LocalBand)
+	maxID := 0
+	for id := range catalog {
+		maxID = max(maxID, id)
+	}
+	for _, b := range current {
+		curBands[b.Artist.ID] = b
+		maxID = max(maxID, b.Artist.ID)
+	}
+	for _, b := range incoming.LocalBands {
+		maxID = max(maxID, b.Artist.ID)
+	}
+
+	remap := make(map[int]int)
+	bands := make([]models.LocalBand, 0, len(incoming.LocalBands))
+	for _, b := range incoming.LocalBands {
+		cur, isLocal := curBands[b.Artist.ID]
+		if catalog[b.Artist.ID] || isLocal && !strings.EqualFold(cur.Artist.Name, b.Artist.Name) {
+			maxID++
+			remap[b.Artist.ID] = maxID
+			b.Artist.ID = maxID
+		}
+		bands = append(bands, b)
+	}
+	if len(remap) == 0 {
+		return incoming
+	}
+
+	out := incoming
+	out.LocalBands = bands
+	out.Favorites = make([]models.Favorite, 0, len(incoming.Favorites))
+	for _, f := range incoming.Favorites {
+		if id, ok := remap[f.ID]; ok {
+			f.ID = id
+		}
+		out.Favorites = append(out.Favorites, f)
+	}
+	out.Collections = make([]models.Collection, 0, len(incoming.Collections))
+	for _, c := range incoming.Collections {
+		ids := make([]int, len(c.ArtistIDs))
+		for i, id := range c.ArtistIDs {
+			if newID, ok := remap[id]; ok {
+				id = newID
+			}
+			ids[i] = id
+		}
+		c.ArtistIDs = ids
+		out.Collections = append(out.Collections, c)
+	}
+	return out
+}
+
+// ApplyImport écrit le résultat d'un plan d'import en tout ou rien : si un
+// fichier ne peut pas être écrit, aucune donnée n'est modifiée (voir writeAll)
+func ApplyImport(plan ImportPlan) error {
+	files := []stagedFile{
+		{favFileName, plan.result.Favorites},
+		{collectionsFileName, plan.result.Collections},
+		{localBandsFileName, plan.result.LocalBands},
+		{presetsFileName, plan.result.Presets},
+	}
+	if plan.PreferencesChanged && plan.result.Preferences != nil {
+		files = append(files, stagedFile{prefsFileName, *plan.result.Preferences})
+	}
+
+	// Même ordre que les autres écritures : verrous du processus puis des fichiers
+	favLock.Lock()
+	collLock.Lock()
+	localBandsLock.Lock()
+	presetsLock.Lock()
+	prefsLock.Lock()
+	favCache, favCacheStat = nil, nil
+	err := writeAll(files)
+	listeners := slices.Collect(maps.Values(favListeners))
+	prefsLock.Unlock()
+	presetsLock.Unlock()
+	localBandsLock.Unlock()
+	collLock.Unlock()
+	favLock.Unlock()
+
+	if err != nil {
+		return err
+	}
+	for _, fn := range listeners {
+		fn()
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"groupie-tracker/models"
+)
+
+func band(id int, name string) models.LocalBand {
+	return models.LocalBand{Artist: models.Artist{ID: id, Name: name}}
+}
+
+func TestBackupRoundTrip(t *testing.T) {
+	data := BackupData{
+		Favorites:   []models.Favorite{{ID: 1, Rating: 4, Tags: []string{"live"}, AddedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}},
+		Collections: []models.Collection{{ID: 1, Name: "Été", ArtistIDs: []int{1, 60}}},
+		LocalBands:  []models.LocalBand{band(60, "Mon groupe")},
+		Presets:     []models.FilterPreset{{Name: "Années 70", MinCreation: "1970", MaxCreation: "1979", Members: []string{"4"}}},
+		Preferences: &Preferences{Language: "EN", ViewMode: "grid"},
+	}
+	var buf bytes.Buffer
+	if err := WriteBackup(&buf, data); err != nil {
+		t.Fatal(err)
+	}
+	raw := buf.Bytes()
+
+	got, err := ReadBackup(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Errorf("ReadBackup = %+v\nattendu %+v", got, data)
+	}
+
+	tests := []struct {
+		name string
+		raw  string
+		want error
+	}{
+		{"données modifiées", strings.Replace(string(raw), `"rating": 4`, `"rating": 5`, 1), ErrBackupChecksum},
+		{"tronqué", string(raw[:len(raw)/2]), ErrBackupFormat},
+		{"version future", strings.Replace(string(raw), `"version": 1`, `"version": 2`, 1), ErrBackupVersion},
+		{"autre format", `{"format": "autre", "version": 1}`, ErrBackupFormat},
+		{"texte", `bonjour`, ErrBackupFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadBackup([]byte(tt.raw)); !errors.Is(err, tt.want) {
+				t.Errorf("ReadBackup = %v, attendu %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// Les exports des versions précédentes restent lisibles
+func TestReadLegacyBackup(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         string
+		favorites   int
+		collections int
+	}{
+		{"liste d'IDs", `[1, 2, 3]`, 3, 0},
+		{"liste de favoris", `[{"id": 1, "rating": 2}]`, 1, 0},
+		{"favoris et collections", `{"favorites": [1], "collections": [{"id": 1, "name": "A", "artistIds": [1]}]}`, 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ReadBackup([]byte(tt.raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(d.Favorites) != tt.favorites || len(d.Collections) != tt.collections {
+				t.Errorf("ReadBackup = %+v", d)
+			}
+		})
+	}
+}
+
+func TestRemapLocalBands(t *testing.T) {
+	catalog := map[int]bool{1: true, 52: true}
+
+	tests := []struct {
+		name     string
+		current  []models.LocalBand
+		incoming []models.LocalBand
+		want     []int // IDs des groupes importés après renumérotation
+	}{
+		{"sans conflit", []models.LocalBand{band(53, "A")}, []models.LocalBand{band(54, "B")}, []int{54}},
+		{"même groupe", []models.LocalBand{band(53, "A")}, []models.LocalBand{band(53, "a")}, []int{53}},
+		{"groupe local différent", []models.LocalBand{band(53, "A")}, []models.LocalBand{band(53, "B")}, []int{54}},
+		{"après les autres groupes importés", []models.LocalBand{band(53, "A")}, []models.LocalBand{band(53, "B"), band(60, "C")}, []int{61, 60}},
+		{"artiste de l'API", nil, []models.LocalBand{band(52, "B")}, []int{53}},
+		{"après le catalogue", []models.LocalBand{band(10, "A")}, []models.LocalBand{band(10, "B")}, []int{53}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incoming := BackupData{
+				LocalBands:  tt.incoming,
+				Favorites:   []models.Favorite{{ID: tt.incoming[0].Artist.ID}, {ID: 1}},
+				Collections: []models.Collection{{Name: "X", ArtistIDs: []int{1, tt.incoming[0].Artist.ID}}},
+			}
+			out := remapLocalBands(tt.current, incoming, catalog)
+
+			var got []int
+			for _, b := range out.LocalBands {
+				got = append(got, b.Artist.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("IDs = %v, attendu %v", got, tt.want)
+			}
+			// Les références au groupe suivent sa renumérotation, pas celles à l'API
+			if out.Favorites[0].ID != tt.want[0] || out.Favorites[1].ID != 1 {
+				t.Errorf("favoris = %+v", out.Favorites)
+			}
+			if ids := out.Collections[0].ArtistIDs; ids[0] != 1 || ids[1] != tt.want[0] {
+				t.Errorf("collection = %v", ids)
+			}
+		})
+	}
+}
+
+func TestPlanImport(t *testing.T) {
+	current := BackupData{
+		Favorites:   []models.Favorite{{ID: 1, Rating: 2}, {ID: 2}},
+		Collections: []models.Collection{{ID: 1, Name: "A", ArtistIDs: []int{1}}},
+		Presets:     []models.FilterPreset{{Name: "Rock", Search: "rock"}, {Name: "Favoris", FavoritesOnly: true}},
+		Preferences: &Preferences{Language: "FR"},
+	}
+	incoming := BackupData{
+		Favorites:   []models.Favorite{{ID: 1, Rating: 5}, {ID: 3}},
+		Collections: []models.Collection{{ID: 1, Name: "B", ArtistIDs: []int{3}}},
+		Presets:     []models.FilterPreset{{Name: "rock", Search: "hard rock"}, {Name: "Récents", MinCreation: "2000"}},
+		Preferences: &Preferences{Language: "DE"},
+	}
+
+	tests := []struct {
+		mode                    ImportMode
+		added, updated, removed []int
+		collAdded, collRemoved  int
+		presets                 []string // noms des préréglages après import
+		presetsRemoved          []string
+		prefsChanged            bool
+	}{
+		{ImportMerge, []int{3}, []int{1}, nil, 1, 0, []string{"rock", "Favoris", "Récents"}, nil, false},
+		{ImportReplace, []int{3}, []int{1}, []int{2}, 1, 1, []string{"rock", "Récents"}, []string{"Favoris"}, true},
+	}
+	for _, tt := range tests {
+		plan := PlanImport(current, incoming, tt.mode, map[int]bool{1: true, 2: true, 3: true})
+		if !slices.Equal(plan.FavoritesAdded, tt.added) || !slices.Equal(plan.FavoritesUpdated, tt.updated) || !slices.Equal(plan.FavoritesRemoved, tt.removed) {
+			t.Errorf("mode %d : favoris +%v ~%v -%v", tt.mode, plan.FavoritesAdded, plan.FavoritesUpdated, plan.FavoritesRemoved)
+		}
+		if len(plan.CollectionsAdded) != tt.collAdded || len(plan.CollectionsRemoved) != tt.collRemoved {
+			t.Errorf("mode %d : collections +%v -%v", tt.mode, plan.CollectionsAdded, plan.CollectionsRemoved)
+		}
+		var presets []string
+		for _, p := range plan.result.Presets {
+			presets = append(presets, p.Name)
+		}
+		// Un préréglage de même nom (sans tenir compte de la casse) est remplacé
+		if !slices.Equal(presets, tt.presets) || !slices.Equal(plan.PresetsAdded, []string{"Récents"}) ||
+			!slices.Equal(plan.PresetsUpdated, []string{"rock"}) || !slices.Equal(plan.PresetsRemoved, tt.presetsRemoved) {
+			t.Errorf("mode %d : préréglages %v, +%v ~%v -%v", tt.mode, presets, plan.PresetsAdded, plan.PresetsUpdated, plan.PresetsRemoved)
+		}
+		if plan.PreferencesChanged != tt.prefsChanged || plan.Empty() {
+			t.Errorf("mode %d : préférences changées = %v", tt.mode, plan.PreferencesChanged)
+		}
+	}
+
+	if !PlanImport(current, current, ImportMerge, nil).Empty() {
+		t.Error("réimporter les données actuelles change quelque chose")
+	}
+}
+
+func TestApplyImport(t *testing.T) {
+	dir := useTempDir(t)
+	if err := SaveFavorites(map[int]bool{1: true}); err != nil {
+		t.Fatal(err)
+	}
+	incoming := BackupData{
+		Favorites:   []models.Favorite{{ID: 2, Rating: 3}},
+		Collections: []models.Collection{{ID: 1, Name: "A", ArtistIDs: []int{2}}},
+		LocalBands:  []models.LocalBand{band(60, "Mon groupe")},
+		Presets:     []models.FilterPreset{{Name: "Rock", Search: "rock"}},
+	}
+	current, err := CurrentBackupData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan := PlanImport(current, incoming, ImportMerge, map[int]bool{1: true, 2: true})
+
+	// Un fichier impossible à écrire : rien ne doit changer
+	blocked := filepath.Join(dir, localBandsFileName)
+	if err := os.Mkdir(blocked, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyImport(plan); err == nil {
+		t.Fatal("ApplyImport réussit malgré un fichier impossible à écrire")
+	}
+	if favs := LoadFavorites(); len(favs) != 1 || !favs[1] {
+		t.Errorf("favoris modifiés par un import échoué : %v", favs)
+	}
+	for _, name := range []string{collectionsFileName, presetsFileName} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s écrit par un import échoué : %v", name, err)
+		}
+	}
+	leftovers, _ := filepath.Glob(filepath.Join(dir, "*.tmp-*"))
+	if len(leftovers) > 0 {
+		t.Errorf("fichiers temporaires restants : %v", leftovers)
+	}
+
+	if err := os.Remove(blocked); err != nil {
+		t.Fatal(err)
+	}
+	notified := 0
+	remove := OnFavoritesChanged(func() { notified++ })
+	defer remove()
+	if err := ApplyImport(plan); err != nil {
+		t.Fatal(err)
+	}
+	after, err := CurrentBackupData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := json.Marshal([]any{after.Favorites, after.Collections, after.LocalBands, after.Presets})
+	want, _ := json.Marshal([]any{plan.result.Favorites, plan.result.Collections, plan.result.LocalBands, plan.result.Presets})
+	if !bytes.Equal(got, want) {
+		t.Errorf("données après import :\n%s\nattendu :\n%s", got, want)
+	}
+	if notified != 1 {
+		t.Errorf("%d notifications des favoris, attendu 1", notified)
+	}
+}
@@ -0,0 +1,113 @@
+package store
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"groupie-tracker/models"
+)
+
+const collectionsFileName = "collections.json"
+
+var collLock sync.Mutex
+
+// LoadCollections lit les collections de l'utilisateur
+func LoadCollections() ([]models.Collection, error) {
+	collLock.Lock()
+	defer collLock.Unlock()
+
+	var cols []models.Collection
+	if err := ReadJSON(collectionsFileName, &cols); err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return cols, nil
+}
+
+// SaveCollections sauvegarde les collections
+func SaveCollections(cols []models.Collection) error {
+	collLock.Lock()
+	defer collLock.Unlock()
+
+	return WriteJSON(collectionsFileName, cols)
+}
+
+// UpdateCollections relit les collections, applique change puis sauvegarde,
+// sous le verrou exclusif du fichier (voir updateJSON)
+func UpdateCollections(change func(cols *[]models.Collection) error) error {
+	collLock.Lock()
+	defer collLock.Unlock()
+
+	return update(collectionsFileName, change)
+}
+
+// CollectionByID retourne la collection id de cols, nil si elle n'existe plus
+func CollectionByID(cols []models.Collection, id int) *models.Collection {
+	for i := range cols {
+		if cols[i].ID == id {
+			return &cols[i]
+		}
+	}
+	return nil
+}
+
+// NextCollectionID retourne un identifiant libre
+func NextCollectionID(cols []models.Collection) int {
+	maxID := 0
+	for _, c := range cols {
+		if c.ID > maxID {
+			maxID = c.ID
+		}
+	}
+	return maxID + 1
+}
+
+// CollectionHas indique si l'artiste fait partie de la collection
+func CollectionHas(c models.Collection, artistID int) bool {
+	for _, id := range c.ArtistIDs {
+		if id == artistID {
+			return true
+		}
+	}
+	return false
+}
+
+// SetCollectionMember ajoute (en fin de liste) ou retire un artiste d'une collection
+func SetCollectionMember(c *models.Collection, artistID int, member bool) {
+	if member {
+		if !CollectionHas(*c, artistID) {
+			c.ArtistIDs = append(c.ArtistIDs, artistID)
+		}
+		return
+	}
+	ids := c.ArtistIDs[:0]
+	for _, id := range c.ArtistIDs {
+		if id != artistID {
+			ids = append(ids, id)
+		}
+	}
+	c.ArtistIDs = ids
+}
+
+// MergeCollections fusionne des collections importées : même nom = remplacée, sinon ajoutée
+func MergeCollections(existing, imported []models.Collection) []models.Collection {
+	merged := append([]models.Collection(nil), existing...)
+	for _, imp := range imported {
+		if strings.TrimSpace(imp.Name) == "" {
+			continue
+		}
+		found := false
+		for i := range merged {
+			if strings.EqualFold(merged[i].Name, imp.Name) {
+				merged[i].ArtistIDs = imp.ArtistIDs
+				found = true
+				break
+			}
+		}
+		if !found {
+			imp.ID = NextCollectionID(merged)
+			merged = append(merged, imp)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,271 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"groupie-tracker/models"
+)
+
+const favFileName = "favorites.json"
+
+// Gestionnaire de favoris avec Mutex pour éviter les conflits entre goroutines
+// (le verrou de fichier protège des autres processus)
+var favLock sync.Mutex
+
+// Copie en mémoire des favoris : elle reste valable tant que le fichier n'a pas
+// été modifié (date et taille identiques), ce qui évite de relire le disque à
+// chaque filtrage.
+var (
+	favCache     map[int]models.Favorite
+	favCacheStat os.FileInfo
+)
+
+// Fonctions prévenues après chaque sauvegarde des favoris, par identifiant
+// d'inscription
+var (
+	favListeners  = make(map[int]func())
+	favListenerID int
+)
+
+// OnFavoritesChanged inscrit fn, appelée après chaque sauvegarde des favoris
+// par ce processus (sur la goroutine qui sauvegarde) ; remove la désinscrit
+func OnFavoritesChanged(fn func()) (remove func()) {
+	favLock.Lock()
+	defer favLock.Unlock()
+
+	favListenerID++
+	id := favListenerID
+	favListeners[id] = fn
+	return func() {
+		favLock.Lock()
+		defer favLock.Unlock()
+		delete(favListeners, id)
+	}
+}
+
+// LoadFavoriteEntries lit les favoris complets (note, étoiles, tags, date d'ajout)
+func LoadFavoriteEntries() (map[int]models.Favorite, error) {
+	favLock.Lock()
+	defer favLock.Unlock()
+
+	info := favFileInfo()
+	if favCache != nil && sameFile(info, favCacheStat) {
+		return maps.Clone(favCache), nil
+	}
+
+	var raw json.RawMessage
+	if err := ReadJSON(favFileName, &raw); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			favCache, favCacheStat = make(map[int]models.Favorite), nil
+			return make(map[int]models.Favorite), nil
+		}
+		return make(map[int]models.Favorite), err
+	}
+	entries, err := DecodeFavorites(raw)
+	if err != nil {
+		return entries, err
+	}
+	// La lecture a pu restaurer une sauvegarde : on mémorise l'état actuel du fichier
+	favCache, favCacheStat = entries, favFileInfo()
+	return maps.Clone(entries), nil
+}
+
+// SaveFavoriteEntries remplace tous les favoris par entries
+func SaveFavoriteEntries(entries map[int]models.Favorite) error {
+	return UpdateFavoriteEntries(func(current map[int]models.Favorite) error {
+		clear(current)
+		maps.Copy(current, entries)
+		return nil
+	})
+}
+
+// UpdateFavoriteEntries relit les favoris, applique change puis sauvegarde :
+// la lecture et l'écriture se font sous le même verrou (du processus et du
+// fichier), une écriture d'un autre processus ou d'une autre goroutine ne peut
+// pas être perdue. Les fonctions inscrites avec OnFavoritesChanged sont
+// ensuite prévenues. Si change retourne une erreur, rien n'est écrit.
+func UpdateFavoriteEntries(change func(entries map[int]models.Favorite) error) error {
+	favLock.Lock()
+	favCache, favCacheStat = nil, nil
+	var saved map[int]models.Favorite
+	err := updateJSON(favFileName, func(data json.RawMessage) (any, error) {
+		entries := make(map[int]models.Favorite)
+		if len(data) > 0 {
+			var err error
+			if entries, err = DecodeFavorites(data); err != nil {
+				return nil, err
+			}
+		}
+		if err := change(entries); err != nil {
+			return nil, err
+		}
+		saved = entries
+		return SortedFavorites(entries), nil
+	})
+	if err != nil || saved == nil {
+		favLock.Unlock()
+		return err
+	}
+	favCache, favCacheStat = maps.Clone(saved), favFileInfo()
+	// Les fonctions sont appelées hors du verrou : elles relisent les favoris
+	listeners := slices.Collect(maps.Values(favListeners))
+	favLock.Unlock()
+
+	for _, fn := range listeners {
+		fn()
+	}
+	return nil
+}
+
+func favFileInfo() os.FileInfo {
+	path, err := Path(favFileName)
+	if err != nil {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	return info
+}
+
+func sameFile(a, b os.FileInfo) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.ModTime().Equal(b.ModTime()) && a.Size() == b.Size()
+}
+
+// LoadFavorites retourne l'ensemble des IDs favoris
+func LoadFavorites() map[int]bool {
+	favs := make(map[int]bool)
+	entries, _ := LoadFavoriteEntries()
+	for id := range entries {
+		favs[id] = true
+	}
+	return favs
+}
+
+// SaveFavorites sauvegarde la map des favoris
+// en conservant les notes déjà saisies pour les artistes toujours favoris.
+func SaveFavorites(favs map[int]bool) error {
+	return UpdateFavoriteEntries(func(entries map[int]models.Favorite) error {
+		maps.DeleteFunc(entries, func(id int, _ models.Favorite) bool { return !favs[id] })
+		for id, isFav := range favs {
+			if _, ok := entries[id]; isFav && !ok {
+				entries[id] = models.Favorite{ID: id, AddedAt: time.Now()}
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateFavorite applique change au favori id (créé s'il n'existe pas) puis sauvegarde
+func UpdateFavorite(id int, change func(f *models.Favorite)) (models.Favorite, error) {
+	var fav models.Favorite
+	err := UpdateFavoriteEntries(func(entries map[int]models.Favorite) error {
+		var ok bool
+		if fav, ok = entries[id]; !ok {
+			fav = models.Favorite{ID: id, AddedAt: time.Now()}
+		}
+		change(&fav)
+		fav.ID = id
+		fav.Rating = min(max(fav.Rating, 0), 5)
+		entries[id] = fav
+		return nil
+	})
+	return fav, err
+}
+
+// ErrNotFavorite est renvoyée par EditFavorite quand l'artiste n'est pas (ou plus) favori
+var ErrNotFavorite = errors.New("store: artiste absent des favoris")
+
+// EditFavorite applique change au favori id puis sauvegarde, comme
+// UpdateFavorite, mais sans créer le favori : si id n'est plus favori (retiré
+// depuis une autre fenêtre ou un autre mode), rien n'est écrit et
+// ErrNotFavorite est renvoyée.
+func EditFavorite(id int, change func(f *models.Favorite)) (models.Favorite, error) {
+	var fav models.Favorite
+	err := UpdateFavoriteEntries(func(entries map[int]models.Favorite) error {
+		var ok bool
+		if fav, ok = entries[id]; !ok {
+			return ErrNotFavorite
+		}
+		change(&fav)
+		fav.ID = id
+		fav.Rating = min(max(fav.Rating, 0), 5)
+		entries[id] = fav
+		return nil
+	})
+	return fav, err
+}
+
+// RemoveFavorite retire id des favoris (sa note et ses tags sont perdus)
+func RemoveFavorite(id int) error {
+	return UpdateFavoriteEntries(func(entries map[int]models.Favorite) error {
+		if _, ok := entries[id]; !ok {
+			return errUnchanged
+		}
+		delete(entries, id)
+		return nil
+	})
+}
+
+// SplitTags découpe une saisie "rock, live ,80s" en tags propres et sans doublon
+func SplitTags(text string) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, t := range strings.Split(text, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t != "" && !seen[t] {
+			seen[t] = true
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// DecodeFavorites accepte l'ancien format (tableau d'IDs) et le nouveau (tableau d'objets)
+func DecodeFavorites(data []byte) (map[int]models.Favorite, error) {
+	entries := make(map[int]models.Favorite)
+
+	var ids []int
+	if err := json.Unmarshal(data, &ids); err == nil {
+		for _, id := range ids {
+			entries[id] = models.Favorite{ID: id}
+		}
+		return entries, nil
+	}
+
+	var list []models.Favorite
+	if err := json.Unmarshal(data, &list); err != nil {
+		return entries, err
+	}
+	for _, f := range list {
+		if f.Rating < 0 {
+			f.Rating = 0
+		} else if f.Rating > 5 {
+			f.Rating = 5
+		}
+		entries[f.ID] = f
+	}
+	return entries, nil
+}
+
+// SortedFavorites retourne les favoris triés par ID (ordre stable pour les fichiers)
+func SortedFavorites(entries map[int]models.Favorite) []models.Favorite {
+	list := make([]models.Favorite, 0, len(entries))
+	for _, f := range entries {
+		list = append(list, f)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
@@ -0,0 +1,144 @@
+package store
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"groupie-tracker/models"
+)
+
+func TestDecodeFavorites(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want map[int]int // ID -> note
+	}{
+		{"ancien format", `[1, 7]`, map[int]int{1: 0, 7: 0}},
+		{"nouveau format", `[{"id": 3, "rating": 4}]`, map[int]int{3: 4}},
+		{"note bornée", `[{"id": 3, "rating": 9}, {"id": 4, "rating": -2}]`, map[int]int{3: 5, 4: 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeFavorites([]byte(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("DecodeFavorites = %v", got)
+			}
+			for id, rating := range tt.want {
+				if f, ok := got[id]; !ok || f.Rating != rating {
+					t.Errorf("favori %d = %+v, attendu note %d", id, f, rating)
+				}
+			}
+		})
+	}
+	if _, err := DecodeFavorites([]byte(`{"id": 1}`)); err == nil {
+		t.Error("DecodeFavorites accepte un objet seul")
+	}
+}
+
+func TestSaveFavoritesKeepsNotes(t *testing.T) {
+	useTempDir(t)
+	if _, err := UpdateFavorite(1, func(f *models.Favorite) { f.Note = "live" }); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveFavorites(map[int]bool{1: true, 2: true, 3: false}); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := LoadFavoriteEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[1].Note != "live" || entries[2].AddedAt.IsZero() {
+		t.Errorf("favoris = %+v", entries)
+	}
+}
+
+// Des mises à jour simultanées ne doivent pas s'écraser entre elles
+func TestConcurrentUpdates(t *testing.T) {
+	useTempDir(t)
+	const n = 20
+
+	notified := 0
+	var mu sync.Mutex
+	remove := OnFavoritesChanged(func() {
+		mu.Lock()
+		notified++
+		mu.Unlock()
+	})
+	defer remove()
+
+	var wg sync.WaitGroup
+	for id := 1; id <= n; id++ {
+		wg.Go(func() {
+			if _, err := UpdateFavorite(id, func(f *models.Favorite) { f.Rating = id % 6 }); err != nil {
+				t.Error(err)
+			}
+		})
+		wg.Go(func() {
+			err := UpdateCollections(func(cols *[]models.Collection) error {
+				*cols = append(*cols, models.Collection{ID: NextCollectionID(*cols), ArtistIDs: []int{id}})
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+
+	entries, _ := LoadFavoriteEntries()
+	if len(entries) != n {
+		t.Errorf("%d favoris sauvegardés, attendu %d", len(entries), n)
+	}
+	cols, _ := LoadCollections()
+	if len(cols) != n {
+		t.Errorf("%d collections sauvegardées, attendu %d", len(cols), n)
+	}
+	if notified != n {
+		t.Errorf("%d notifications, attendu %d", notified, n)
+	}
+}
+
+func TestRemoveFavorite(t *testing.T) {
+	useTempDir(t)
+	if _, err := UpdateFavorite(5, func(*models.Favorite) {}); err != nil {
+		t.Fatal(err)
+	}
+
+	notified := 0
+	remove := OnFavoritesChanged(func() { notified++ })
+	defer remove()
+
+	// Retirer un artiste absent n'écrit rien et ne prévient personne
+	if err := RemoveFavorite(6); err != nil || notified != 0 {
+		t.Fatalf("RemoveFavorite(6) = %v, %d notifications", err, notified)
+	}
+	if err := RemoveFavorite(5); err != nil || notified != 1 {
+		t.Fatalf("RemoveFavorite(5) = %v, %d notifications", err, notified)
+	}
+	if favs := LoadFavorites(); len(favs) != 0 {
+		t.Errorf("favoris restants : %v", favs)
+	}
+}
+
+func TestEditFavorite(t *testing.T) {
+	useTempDir(t)
+	if _, err := UpdateFavorite(1, func(f *models.Favorite) { f.Rating = 2 }); err != nil {
+		t.Fatal(err)
+	}
+
+	fav, err := EditFavorite(1, func(f *models.Favorite) { f.Note = "live" })
+	if err != nil || fav.Note != "live" || fav.Rating != 2 {
+		t.Errorf("EditFavorite = %+v, %v", fav, err)
+	}
+	// Un artiste retiré entre-temps n'est pas recréé
+	if _, err := EditFavorite(2, func(f *models.Favorite) { f.Note = "x" }); !errors.Is(err, ErrNotFavorite) {
+		t.Errorf("EditFavorite(non favori) = %v, attendu ErrNotFavorite", err)
+	}
+	if favs := LoadFavorites(); len(favs) != 1 {
+		t.Errorf("favoris = %v", favs)
+	}
+}
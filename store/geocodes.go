@@ -0,0 +1,51 @@
+package store
+
+import (
+	"errors"
+	"sync"
+
+	"groupie-tracker/models"
+)
+
+const geocodesFileName = "geocodes.json"
+
+var geocodesLock sync.Mutex
+
+// LoadGeocodes lit les coordonnées des lieux déjà géocodés, par lieu lisible
+// en minuscules. Tous les modes de l'application partagent ce fichier : le
+// proxy et les exports profitent des lieux trouvés par l'interface.
+func LoadGeocodes() (map[string]models.Coordinates, error) {
+	geocodesLock.Lock()
+	defer geocodesLock.Unlock()
+
+	geocodes := make(map[string]models.Coordinates)
+	if err := ReadJSON(geocodesFileName, &geocodes); err != nil && !errors.Is(err, ErrNotFound) {
+		return make(map[string]models.Coordinates), err
+	}
+	return geocodes, nil
+}
+
+// SaveGeocode ajoute les coordonnées d'un lieu à celles déjà enregistrées
+func SaveGeocode(place string, c models.Coordinates) error {
+	geocodesLock.Lock()
+	defer geocodesLock.Unlock()
+
+	return update(geocodesFileName, func(geocodes *map[string]models.Coordinates) error {
+		if *geocodes == nil {
+			*geocodes = make(map[string]models.Coordinates)
+		}
+		if (*geocodes)[place] == c {
+			return errUnchanged
+		}
+		(*geocodes)[place] = c
+		return nil
+	})
+}
+
+// Geocodes donne accès au fichier des lieux géocodés à travers
+// api.GeocodeStore
+type Geocodes struct{}
+
+func (Geocodes) Load() (map[string]models.Coordinates, error) { return LoadGeocodes() }
+
+func (Geocodes) Save(place string, c models.Coordinates) error { return SaveGeocode(place, c) }
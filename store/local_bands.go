@@ -0,0 +1,48 @@
+package store
+
+import (
+	"errors"
+	"sync"
+
+	"groupie-tracker/models"
+)
+
+const localBandsFileName = "local_bands.json"
+
+var localBandsLock sync.Mutex
+
+// LoadLocalBands lit les groupes créés par l'utilisateur
+func LoadLocalBands() ([]models.LocalBand, error) {
+	localBandsLock.Lock()
+	defer localBandsLock.Unlock()
+
+	var bands []models.LocalBand
+	if err := ReadJSON(localBandsFileName, &bands); err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return bands, nil
+}
+
+// SaveLocalBands sauvegarde les groupes créés par l'utilisateur
+func SaveLocalBands(bands []models.LocalBand) error {
+	localBandsLock.Lock()
+	defer localBandsLock.Unlock()
+
+	return WriteJSON(localBandsFileName, bands)
+}
+
+// AddLocalBand ajoute un groupe à ceux déjà enregistrés ; la lecture et
+// l'écriture se font sous le même verrou exclusif. Si un autre processus a
+// ajouté un groupe entre-temps, l'ID est décalé après le sien.
+func AddLocalBand(band models.LocalBand) error {
+	localBandsLock.Lock()
+	defer localBandsLock.Unlock()
+
+	return update(localBandsFileName, func(bands *[]models.LocalBand) error {
+		for _, b := range *bands {
+			band.Artist.ID = max(band.Artist.ID, b.Artist.ID+1)
+		}
+		*bands = append(*bands, band)
+		return nil
+	})
+}
@@ -0,0 +1,21 @@
+package store
+
+import (
+	"testing"
+
+	"groupie-tracker/models"
+)
+
+func TestAddLocalBandShiftsID(t *testing.T) {
+	useTempDir(t)
+	for _, id := range []int{100, 100, 50} {
+		if err := AddLocalBand(models.LocalBand{Artist: models.Artist{ID: id}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bands, _ := LoadLocalBands()
+	got := []int{bands[0].Artist.ID, bands[1].Artist.ID, bands[2].Artist.ID}
+	if got[0] != 100 || got[1] != 101 || got[2] != 102 {
+		t.Errorf("IDs = %v, attendu [100 101 102]", got)
+	}
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"errors"
+	"sync"
+)
+
+const prefsFileName = "preferences.json"
+
+// Preferences regroupe les réglages de l'interface restaurés au démarrage
+type Preferences struct {
+	Language     string  `json:"language"`        // FR, EN, ES, DE
+	Theme        string  `json:"theme,omitempty"` // "dark", "light" ou vide (thème du système)
+	ViewMode     string  `json:"viewMode"`        // "list" ou "grid"
+	SortOption   string  `json:"sortOption,omitempty"`
+	WindowWidth  float32 `json:"windowWidth"`
+	WindowHeight float32 `json:"windowHeight"`
+	SplitOffset  float64 `json:"splitOffset"` // séparation de la fiche artiste
+}
+
+// DefaultPreferences correspond au comportement historique de l'application
+func DefaultPreferences() Preferences {
+	return Preferences{
+		Language:     "FR",
+		ViewMode:     "list",
+		WindowWidth:  800,
+		WindowHeight: 600,
+		SplitOffset:  0.35,
+	}
+}
+
+var prefsLock sync.Mutex
+
+// LoadPreferences lit les préférences ; les valeurs absentes gardent leur défaut
+func LoadPreferences() (Preferences, error) {
+	prefsLock.Lock()
+	defer prefsLock.Unlock()
+
+	p := DefaultPreferences()
+	if err := ReadJSON(prefsFileName, &p); err != nil && !errors.Is(err, ErrNotFound) {
+		return DefaultPreferences(), err
+	}
+	if p.WindowWidth <= 0 || p.WindowHeight <= 0 {
+		p.WindowWidth, p.WindowHeight = 800, 600
+	}
+	if p.SplitOffset <= 0 || p.SplitOffset >= 1 {
+		p.SplitOffset = 0.35
+	}
+	return p, nil
+}
+
+// SavePreferences sauvegarde les préférences
+func SavePreferences(p Preferences) error {
+	prefsLock.Lock()
+	defer prefsLock.Unlock()
+
+	return WriteJSON(prefsFileName, p)
+}
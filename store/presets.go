@@ -0,0 +1,53 @@
+package store
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"groupie-tracker/models"
+)
+
+const presetsFileName = "presets.json"
+
+var presetsLock sync.Mutex
+
+// LoadPresets lit les préréglages de filtres
+func LoadPresets() ([]models.FilterPreset, error) {
+	presetsLock.Lock()
+	defer presetsLock.Unlock()
+
+	var presets []models.FilterPreset
+	if err := ReadJSON(presetsFileName, &presets); err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return presets, nil
+}
+
+// SavePresets sauvegarde les préréglages de filtres
+func SavePresets(presets []models.FilterPreset) error {
+	presetsLock.Lock()
+	defer presetsLock.Unlock()
+
+	return WriteJSON(presetsFileName, presets)
+}
+
+// UpdatePresets relit les préréglages, applique change puis sauvegarde,
+// sous le verrou exclusif du fichier (voir updateJSON)
+func UpdatePresets(change func(presets *[]models.FilterPreset) error) error {
+	presetsLock.Lock()
+	defer presetsLock.Unlock()
+
+	return update(presetsFileName, change)
+}
+
+// UpsertPreset remplace le préréglage de même nom ou l'ajoute en fin de liste
+func UpsertPreset(presets []models.FilterPreset, p models.FilterPreset) []models.FilterPreset {
+	for i := range presets {
+		if strings.EqualFold(presets[i].Name, p.Name) {
+			presets[i] = p
+			return presets
+		}
+	}
+	return append(presets, p)
+}
@@ -0,0 +1,369 @@
+// Package store gère la persistance des données utilisateur (favoris, collections, ...)
+// dans le dossier de configuration du système, avec écritures atomiques,
+// verrouillage entre processus, rotation des sauvegardes et récupération
+// des fichiers corrompus.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+const (
+	appDirName = "groupie-tracker"
+	// Nombre de copies conservées : nom.json.bak.1 (la plus récente) à nom.json.bak.N
+	maxBackups = 3
+)
+
+// ErrNotFound est renvoyée quand aucune donnée n'existe encore pour un fichier
+var ErrNotFound = errors.New("store: aucune donnée")
+
+// Dir retourne (et crée si besoin) le dossier de données de l'application.
+// La variable d'environnement GROUPIE_TRACKER_HOME permet de le remplacer.
+func Dir() (string, error) {
+	dir := os.Getenv("GROUPIE_TRACKER_HOME")
+	if dir == "" {
+		base, err := os.UserConfigDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(base, appDirName)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// Path retourne le chemin complet d'un fichier du dossier de données
+func Path(name string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// ReadJSON décode le fichier name dans v.
+// Si le fichier est corrompu, il est mis de côté (nom.corrupt-<date>) et la
+// sauvegarde valide la plus récente est restaurée à sa place.
+func ReadJSON(name string, v any) error {
+	path, err := Path(name)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := lockFile(path, false)
+	if err != nil {
+		return err
+	}
+	data, readErr := os.ReadFile(path)
+	unlock()
+
+	if readErr == nil && json.Unmarshal(data, v) == nil {
+		return nil
+	}
+	if readErr != nil && !errors.Is(readErr, os.ErrNotExist) {
+		return readErr
+	}
+
+	unlock, err = lockFile(path, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return recoverFile(name, path, v, readErr == nil)
+}
+
+// WriteJSON encode v et remplace le fichier name de façon atomique :
+// écriture dans un fichier temporaire, fsync, copie de l'ancienne version
+// dans les sauvegardes puis renommage.
+func WriteJSON(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	path, err := Path(name)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := lockFile(path, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := rotateBackups(path); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// updateJSON relit le fichier name, passe son contenu à change (nil s'il
+// n'existe pas encore) et écrit la valeur retournée, le tout sous le verrou
+// exclusif : un autre processus ne peut pas écrire entre la lecture et
+// l'écriture. Si change retourne errUnchanged, rien n'est écrit.
+func updateJSON(name string, change func(data json.RawMessage) (any, error)) error {
+	path, err := Path(name)
+	if err != nil {
+		return err
+	}
+	unlock, err := lockFile(path, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	var current json.RawMessage
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil && json.Unmarshal(data, &current) == nil:
+	case err != nil && !errors.Is(err, os.ErrNotExist):
+		return err
+	default:
+		if err := recoverFile(name, path, &current, err == nil); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+
+	v, err := change(current)
+	if errors.Is(err, errUnchanged) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if data, err = json.MarshalIndent(v, "", "  "); err != nil {
+		return err
+	}
+	if err := rotateBackups(path); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// errUnchanged, retournée par la fonction passée à updateJSON, évite une
+// écriture inutile
+var errUnchanged = errors.New("store: aucun changement")
+
+// update est updateJSON pour un fichier contenant une valeur de type T
+func update[T any](name string, change func(v *T) error) error {
+	return updateJSON(name, func(data json.RawMessage) (any, error) {
+		var v T
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &v); err != nil {
+				return nil, err
+			}
+		}
+		if err := change(&v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
+
+// recoverFile tente, dans l'ordre, les sauvegardes puis l'ancien fichier
+// relatif au dossier courant (versions précédentes de l'application).
+// L'appelant détient le verrou exclusif du fichier.
+func recoverFile(name, path string, v any, corrupt bool) error {
+	// Un autre processus a pu réparer ou créer le fichier entre-temps
+	if data, err := os.ReadFile(path); err == nil && json.Unmarshal(data, v) == nil {
+		return nil
+	} else if err == nil {
+		corrupt = true
+	}
+
+	if corrupt {
+		quarantine := fmt.Sprintf("%s.corrupt-%s", path, time.Now().Format("20060102-150405"))
+		if err := os.Rename(path, quarantine); err != nil {
+			return err
+		}
+	}
+
+	candidates := make([]string, 0, maxBackups+1)
+	for i := 1; i <= maxBackups; i++ {
+		candidates = append(candidates, backupPath(path, i))
+	}
+	if !corrupt {
+		candidates = append(candidates, name)
+	}
+
+	for _, candidate := range candidates {
+		data, err := os.ReadFile(candidate)
+		if err != nil || json.Unmarshal(data, v) != nil {
+			continue
+		}
+		if err := writeFileAtomic(path, data); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if corrupt {
+		return fmt.Errorf("store: %s corrompu et aucune sauvegarde valide", name)
+	}
+	return ErrNotFound
+}
+
+// stagedFile est un fichier écrit avec d'autres par writeAll
+type stagedFile struct {
+	name string
+	v    any
+}
+
+// writeAll écrit plusieurs fichiers en tout ou rien. Sous le verrou exclusif
+// de chacun, tous les contenus sont d'abord écrits dans des fichiers
+// temporaires ; les fichiers ne sont remplacés que si toutes ces écritures
+// ont réussi. Si un remplacement échoue, ceux déjà faits sont annulés en
+// réécrivant la version précédente.
+//
+// Les verrous sont pris une seule fois par fichier (le dernier contenu d'un
+// nom répété l'emporte) et toujours dans l'ordre des noms : deux appels
+// simultanés ne peuvent pas s'attendre l'un l'autre.
+func writeAll(files []stagedFile) error {
+	contents := make(map[string]any, len(files))
+	for _, f := range files {
+		contents[f.name] = f.v
+	}
+	files = files[:0:0]
+	for _, name := range slices.Sorted(maps.Keys(contents)) {
+		files = append(files, stagedFile{name, contents[name]})
+	}
+
+	type staged struct {
+		path, tmp string
+		previous  []byte // nil si le fichier n'existait pas
+	}
+	var all []staged
+	defer func() {
+		for _, st := range all {
+			os.Remove(st.tmp) // sans effet une fois renommé
+		}
+	}()
+
+	for _, f := range files {
+		data, err := json.MarshalIndent(f.v, "", "  ")
+		if err != nil {
+			return err
+		}
+		path, err := Path(f.name)
+		if err != nil {
+			return err
+		}
+		unlock, err := lockFile(path, true)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		previous, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+		if err != nil {
+			return err
+		}
+		all = append(all, staged{path: path, tmp: tmp.Name(), previous: previous})
+		_, err = tmp.Write(data)
+		if err == nil {
+			err = tmp.Sync()
+		}
+		if cerr := tmp.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, st := range all {
+		err := rotateBackups(st.path)
+		if err == nil {
+			err = os.Rename(st.tmp, st.path)
+		}
+		if err == nil {
+			continue
+		}
+		for _, done := range all[:i] {
+			if done.previous == nil {
+				os.Remove(done.path)
+			} else {
+				writeFileAtomic(done.path, done.previous)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func backupPath(path string, n int) string {
+	return fmt.Sprintf("%s.bak.%d", path, n)
+}
+
+// rotateBackups décale nom.bak.1 -> nom.bak.2 ... puis copie la version actuelle en nom.bak.1
+func rotateBackups(path string) error {
+	current, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !json.Valid(current) {
+		// Inutile de conserver une version illisible
+		return nil
+	}
+
+	for i := maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(backupPath(path, i), backupPath(path, i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return writeFileAtomic(backupPath(path, 1), current)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // sans effet une fois renommé
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, path)
+}
+
+// lockFile pose un verrou (partagé ou exclusif) sur nom.lock, valable entre processus
+func lockFile(path string, exclusive bool) (func(), error) {
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockHandle(f, exclusive); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() {
+		unlockHandle(f)
+		f.Close()
+	}, nil
+}
@@ -0,0 +1,150 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// useTempDir isole un test dans un dossier de données vide
+func useTempDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("GROUPIE_TRACKER_HOME", dir)
+	favLock.Lock()
+	favCache, favCacheStat = nil, nil
+	favLock.Unlock()
+	return dir
+}
+
+func TestReadJSONNotFound(t *testing.T) {
+	useTempDir(t)
+	var v []int
+	if err := ReadJSON("absent.json", &v); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("ReadJSON = %v, attendu ErrNotFound", err)
+	}
+}
+
+func TestWriteJSONRoundTripAndBackups(t *testing.T) {
+	dir := useTempDir(t)
+	for i := 1; i <= maxBackups+2; i++ {
+		if err := WriteJSON("data.json", []int{i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []int
+	if err := ReadJSON("data.json", &got); err != nil || len(got) != 1 || got[0] != maxBackups+2 {
+		t.Fatalf("ReadJSON = %v, %v", got, err)
+	}
+
+	// nom.bak.1 est la version précédente, seules maxBackups copies sont gardées
+	path := filepath.Join(dir, "data.json")
+	for n := 1; n <= maxBackups; n++ {
+		data, err := os.ReadFile(backupPath(path, n))
+		if err != nil {
+			t.Fatalf("sauvegarde %d : %v", n, err)
+		}
+		var v []int
+		if err := json.Unmarshal(data, &v); err != nil || v[0] != maxBackups+2-n {
+			t.Errorf("sauvegarde %d = %s, attendu [%d]", n, data, maxBackups+2-n)
+		}
+	}
+	if _, err := os.Stat(backupPath(path, maxBackups+1)); !os.IsNotExist(err) {
+		t.Errorf("sauvegarde %d en trop", maxBackups+1)
+	}
+
+	// Aucun fichier temporaire ne doit rester après les renommages
+	tmps, _ := filepath.Glob(filepath.Join(dir, "*.tmp-*"))
+	if len(tmps) > 0 {
+		t.Errorf("fichiers temporaires restants : %v", tmps)
+	}
+}
+
+func TestReadJSONRecovery(t *testing.T) {
+	tests := []struct {
+		name    string
+		backups []string // contenu de nom.bak.1, nom.bak.2...
+		want    []int
+		wantErr bool
+	}{
+		{"sauvegarde récente", []string{"[2]", "[1]"}, []int{2}, false},
+		{"sauvegarde récente corrompue", []string{"{", "[1]"}, []int{1}, false},
+		{"aucune sauvegarde valide", []string{"{"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := useTempDir(t)
+			path := filepath.Join(dir, "data.json")
+			if err := os.WriteFile(path, []byte("[1, 2"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			for i, content := range tt.backups {
+				if err := os.WriteFile(backupPath(path, i+1), []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var got []int
+			err := ReadJSON("data.json", &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ReadJSON = %v, attendu une erreur", got)
+				}
+			} else if err != nil || len(got) != 1 || got[0] != tt.want[0] {
+				t.Fatalf("ReadJSON = %v, %v ; attendu %v", got, err, tt.want)
+			}
+
+			// Le fichier corrompu est mis de côté, jamais supprimé
+			quarantined, _ := filepath.Glob(path + ".corrupt-*")
+			if len(quarantined) != 1 {
+				t.Errorf("fichiers mis de côté : %v", quarantined)
+			}
+		})
+	}
+}
+
+// Deux écritures groupées dans des ordres opposés, ou qui répètent un fichier,
+// ne doivent jamais se bloquer
+func TestWriteAllLockOrder(t *testing.T) {
+	useTempDir(t)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		for i := range 20 {
+			wg.Go(func() {
+				if err := writeAll([]stagedFile{{"a.json", i}, {"b.json", i}}); err != nil {
+					t.Error(err)
+				}
+			})
+			wg.Go(func() {
+				if err := writeAll([]stagedFile{{"b.json", -i}, {"a.json", -i}}); err != nil {
+					t.Error(err)
+				}
+			})
+		}
+		wg.Wait()
+
+		if err := writeAll([]stagedFile{{"a.json", 1}, {"b.json", 2}, {"a.json", 3}}); err != nil {
+			t.Error(err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("writeAll bloqué")
+	}
+
+	for name, want := range map[string]int{"a.json": 3, "b.json": 2} {
+		var got int
+		if err := ReadJSON(name, &got); err != nil || got != want {
+			t.Errorf("%s = %d, %v ; attendu %d", name, got, err, want)
+		}
+	}
+}
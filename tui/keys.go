@@ -0,0 +1,101 @@
+package tui
+
+import "unicode/utf8"
+
+type keyCode int
+
+const (
+	keyRune keyCode = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyPgUp
+	keyPgDn
+	keyHome
+	keyEnd
+	keyEnter
+	keyEsc
+	keyBackspace
+	keyTab
+	keyCtrlC
+)
+
+// key est une touche lue au clavier ; r n'est utilisé que pour keyRune
+type key struct {
+	code keyCode
+	r    rune
+}
+
+// Séquences d'échappement envoyées par les terminaux courants
+var escapeKeys = map[string]keyCode{
+	"[A": keyUp, "[B": keyDown, "[C": keyRight, "[D": keyLeft,
+	"OA": keyUp, "OB": keyDown, "OC": keyRight, "OD": keyLeft,
+	"[5~": keyPgUp, "[6~": keyPgDn,
+	"[H": keyHome, "[F": keyEnd, "OH": keyHome, "OF": keyEnd,
+	"[1~": keyHome, "[4~": keyEnd, "[7~": keyHome, "[8~": keyEnd,
+}
+
+// parseKeys découpe les octets lus en touches (un collage peut en contenir plusieurs)
+func parseKeys(buf []byte) []key {
+	var keys []key
+	for len(buf) > 0 {
+		switch b := buf[0]; {
+		case b == 0x1b:
+			if len(buf) == 1 {
+				return append(keys, key{code: keyEsc})
+			}
+			n, code := matchEscape(buf[1:])
+			if n == 0 {
+				// Échap suivi d'autre chose : Échap seul
+				keys = append(keys, key{code: keyEsc})
+				buf = buf[1:]
+				continue
+			}
+			if code >= 0 {
+				keys = append(keys, key{code: code})
+			}
+			buf = buf[1+n:]
+		case b == '\r' || b == '\n':
+			keys = append(keys, key{code: keyEnter})
+			buf = buf[1:]
+		case b == 0x7f || b == 0x08:
+			keys = append(keys, key{code: keyBackspace})
+			buf = buf[1:]
+		case b == '\t':
+			keys = append(keys, key{code: keyTab})
+			buf = buf[1:]
+		case b == 0x03:
+			keys = append(keys, key{code: keyCtrlC})
+			buf = buf[1:]
+		case b < 0x20:
+			buf = buf[1:] // autres touches de contrôle ignorées
+		default:
+			r, size := utf8.DecodeRune(buf)
+			keys = append(keys, key{code: keyRune, r: r})
+			buf = buf[size:]
+		}
+	}
+	return keys
+}
+
+// matchEscape reconnaît une séquence après Échap ; retourne sa longueur
+// (0 si ce n'en est pas une) et la touche (-1 si la séquence est inconnue)
+func matchEscape(buf []byte) (int, keyCode) {
+	if len(buf) == 0 || (buf[0] != '[' && buf[0] != 'O') {
+		return 0, -1
+	}
+	// Fin de séquence CSI : premier octet entre '@' et '~' après l'introducteur
+	end := 1
+	for end < len(buf) && (buf[end] < '@' || buf[end] > '~') {
+		end++
+	}
+	if end >= len(buf) {
+		return len(buf), -1
+	}
+	seq := string(buf[:end+1])
+	if code, ok := escapeKeys[seq]; ok {
+		return end + 1, code
+	}
+	return end + 1, -1
+}
@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []key
+	}{
+		{"lettre", "a", []key{{code: keyRune, r: 'a'}}},
+		{"accent", "é", []key{{code: keyRune, r: 'é'}}},
+		{"collage", "ab\r", []key{{code: keyRune, r: 'a'}, {code: keyRune, r: 'b'}, {code: keyEnter}}},
+		{"entrée", "\n", []key{{code: keyEnter}}},
+		{"effacement", "\x7f\x08", []key{{code: keyBackspace}, {code: keyBackspace}}},
+		{"tabulation", "\t", []key{{code: keyTab}}},
+		{"ctrl+c", "\x03", []key{{code: keyCtrlC}}},
+		{"contrôle ignoré", "\x01x", []key{{code: keyRune, r: 'x'}}},
+		{"échap seul", "\x1b", []key{{code: keyEsc}}},
+		{"échap puis lettre", "\x1bq", []key{{code: keyEsc}, {code: keyRune, r: 'q'}}},
+		{"flèches CSI", "\x1b[A\x1b[B\x1b[C\x1b[D", []key{{code: keyUp}, {code: keyDown}, {code: keyRight}, {code: keyLeft}}},
+		{"flèches SS3", "\x1bOA\x1bOD", []key{{code: keyUp}, {code: keyLeft}}},
+		{"pages", "\x1b[5~\x1b[6~", []key{{code: keyPgUp}, {code: keyPgDn}}},
+		{"début et fin", "\x1b[H\x1b[4~\x1bOF\x1b[1~", []key{{code: keyHome}, {code: keyEnd}, {code: keyEnd}, {code: keyHome}}},
+		{"séquence inconnue ignorée", "\x1b[15~z", []key{{code: keyRune, r: 'z'}}},
+		{"modificateurs ignorés", "\x1b[1;5Ck", []key{{code: keyRune, r: 'k'}}},
+		{"séquence tronquée", "\x1b[1", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseKeys([]byte(tt.in)); !slices.Equal(got, tt.want) {
+				t.Errorf("parseKeys(%q) = %v, attendu %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
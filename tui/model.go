@@ -0,0 +1,425 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"groupie-tracker/catalog"
+	"groupie-tracker/filter"
+	"groupie-tracker/models"
+	"groupie-tracker/store"
+)
+
+type screen int
+
+const (
+	screenList screen = iota
+	screenDetail
+)
+
+// prompt est la ligne de saisie affichée en bas de l'écran (recherche, filtres)
+type prompt struct {
+	label string
+	text  string
+	live  bool // appliqué à chaque frappe (recherche) ou seulement avec Entrée
+	apply func(m *model, text string) error
+}
+
+// model contient l'état de l'interface ; il ne touche pas au terminal,
+// update modifie l'état et view produit l'écran
+type model struct {
+	cat  *catalog.Catalog
+	idx  *filter.Index
+	favs map[int]models.Favorite
+
+	crit    filter.Criteria
+	results []models.Artist
+	cursor  int
+	offset  int
+
+	screen      screen
+	detail      models.Artist
+	concerts    []models.Concert
+	concertsErr error
+	loading     bool
+	scroll      int
+
+	prompt *prompt
+	status string
+
+	width, height int
+
+	// async lance un chargement en arrière-plan ; le résultat est appliqué
+	// sur la boucle principale par la fonction retournée
+	async func(load func() func(m *model))
+}
+
+func newModel(cat *catalog.Catalog) *model {
+	m := &model{
+		cat:  cat,
+		idx:  cat.Index(),
+		crit: filter.Criteria{Sort: filter.SortNameAsc},
+	}
+	m.favs, _ = store.LoadFavoriteEntries()
+	m.refilter()
+	return m
+}
+
+// refilter relance le filtrage en gardant si possible l'artiste sélectionné
+func (m *model) refilter() {
+	selected := -1
+	if a, ok := m.selected(); ok {
+		selected = a.ID
+	}
+	m.results = m.idx.Apply(m.crit, m.favs)
+	// Artiste disparu des résultats : on reste à la même hauteur
+	m.cursor = min(m.cursor, max(len(m.results)-1, 0))
+	for i, a := range m.results {
+		if a.ID == selected {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+func (m *model) selected() (models.Artist, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.results) {
+		return models.Artist{}, false
+	}
+	return m.results[m.cursor], true
+}
+
+// listHeight est le nombre de lignes disponibles pour la liste
+func (m *model) listHeight() int {
+	return max(m.height-6, 1)
+}
+
+// update traite une touche ; retourne false pour quitter
+func (m *model) update(k key) bool {
+	if k.code == keyCtrlC {
+		return false
+	}
+	if m.prompt != nil {
+		m.updatePrompt(k)
+		return true
+	}
+	m.status = ""
+	if m.screen == screenDetail {
+		return m.updateDetail(k)
+	}
+	return m.updateList(k)
+}
+
+func (m *model) updatePrompt(k key) {
+	p := m.prompt
+	switch k.code {
+	case keyEnter:
+		m.prompt = nil
+		if err := p.apply(m, p.text); err != nil {
+			m.status = err.Error()
+		}
+		return
+	case keyEsc:
+		m.prompt = nil
+		if p.live {
+			p.apply(m, p.text)
+		}
+		return
+	case keyBackspace:
+		if r := []rune(p.text); len(r) > 0 {
+			p.text = string(r[:len(r)-1])
+		}
+	case keyRune:
+		p.text += string(k.r)
+	default:
+		return
+	}
+	if p.live {
+		p.apply(m, p.text)
+	}
+}
+
+func (m *model) move(delta int) {
+	m.cursor = min(max(m.cursor+delta, 0), max(len(m.results)-1, 0))
+}
+
+func (m *model) updateList(k key) bool {
+	page := m.listHeight()
+	switch k.code {
+	case keyUp:
+		m.move(-1)
+	case keyDown:
+		m.move(1)
+	case keyPgUp:
+		m.move(-page)
+	case keyPgDn:
+		m.move(page)
+	case keyHome:
+		m.move(-len(m.results))
+	case keyEnd:
+		m.move(len(m.results))
+	case keyEnter, keyRight:
+		if a, ok := m.selected(); ok {
+			m.openDetail(a)
+		}
+	case keyEsc:
+		m.resetFilters()
+	case keyRune:
+		switch k.r {
+		case 'q':
+			return false
+		case 'k':
+			m.move(-1)
+		case 'j':
+			m.move(1)
+		case 'g':
+			m.move(-len(m.results))
+		case 'G':
+			m.move(len(m.results))
+		case '/':
+			m.prompt = &prompt{label: "Recherche", text: m.crit.Search, live: true, apply: func(m *model, s string) error {
+				m.crit.Search = s
+				m.refilter()
+				return nil
+			}}
+		case 'l':
+			m.prompt = &prompt{label: "Lieu de concert", text: m.crit.Location, live: true, apply: func(m *model, s string) error {
+				m.crit.Location = s
+				m.refilter()
+				return nil
+			}}
+		case 'c':
+			m.prompt = &prompt{label: "Création (ex: 1970-1990)", text: formatRange(m.crit.MinCreation, m.crit.MaxCreation), apply: func(m *model, s string) error {
+				lo, hi, err := parseRange(s)
+				if err != nil {
+					return err
+				}
+				m.crit.MinCreation, m.crit.MaxCreation = lo, hi
+				m.refilter()
+				return nil
+			}}
+		case 'a':
+			m.prompt = &prompt{label: "Premier album (ex: 1980-2000)", text: formatRange(m.crit.MinAlbum, m.crit.MaxAlbum), apply: func(m *model, s string) error {
+				lo, hi, err := parseRange(s)
+				if err != nil {
+					return err
+				}
+				m.crit.MinAlbum, m.crit.MaxAlbum = lo, hi
+				m.refilter()
+				return nil
+			}}
+		case 'm':
+			m.prompt = &prompt{label: "Membres (ex: 2,3,8)", text: formatMembers(m.crit.Members), apply: func(m *model, s string) error {
+				members, err := parseMembers(s)
+				if err != nil {
+					return err
+				}
+				m.crit.Members = members
+				m.refilter()
+				return nil
+			}}
+		case 't':
+			m.prompt = &prompt{label: "Tag", text: m.crit.Tag, live: true, apply: func(m *model, s string) error {
+				m.crit.Tag = s
+				m.refilter()
+				return nil
+			}}
+		case '*':
+			m.crit.MinRating = (m.crit.MinRating + 1) % 6
+			m.refilter()
+		case 'F':
+			m.crit.FavoritesOnly = !m.crit.FavoritesOnly
+			m.refilter()
+		case 's':
+			m.crit.Sort = nextSort(m.crit.Sort)
+			m.refilter()
+		case 'x':
+			m.resetFilters()
+		case 'f':
+			if a, ok := m.selected(); ok {
+				m.toggleFavorite(a)
+			}
+		case 'r':
+			m.reloadFavorites()
+		}
+	}
+	return true
+}
+
+func (m *model) updateDetail(k key) bool {
+	switch k.code {
+	case keyEsc, keyBackspace, keyLeft:
+		m.screen = screenList
+		m.refilter()
+	case keyUp:
+		m.scroll = max(m.scroll-1, 0)
+	case keyDown:
+		m.scroll = min(m.scroll+1, max(len(m.concerts)-1, 0))
+	case keyPgUp:
+		m.scroll = max(m.scroll-m.listHeight(), 0)
+	case keyPgDn:
+		m.scroll = min(m.scroll+m.listHeight(), max(len(m.concerts)-1, 0))
+	case keyRune:
+		switch {
+		case k.r == 'q':
+			return false
+		case k.r == 'f':
+			m.toggleFavorite(m.detail)
+		case k.r >= '0' && k.r <= '5':
+			m.setRating(m.detail, int(k.r-'0'))
+		case k.r == 'k':
+			m.scroll = max(m.scroll-1, 0)
+		case k.r == 'j':
+			m.scroll = min(m.scroll+1, max(len(m.concerts)-1, 0))
+		}
+	}
+	return true
+}
+
+func (m *model) resetFilters() {
+	m.crit = filter.Criteria{Sort: m.crit.Sort}
+	m.refilter()
+}
+
+// openDetail affiche la fiche d'un artiste ; les concerts sont chargés en arrière-plan
+func (m *model) openDetail(a models.Artist) {
+	m.screen = screenDetail
+	m.detail = a
+	m.concerts, m.concertsErr = nil, nil
+	m.scroll = 0
+	m.loading = true
+
+	id := a.ID
+	cat := m.cat
+	m.async(func() func(m *model) {
+		concerts, err := cat.Concerts(id)
+		return func(m *model) {
+			if m.detail.ID != id {
+				return
+			}
+			m.concerts, m.concertsErr, m.loading = concerts, err, false
+		}
+	})
+}
+
+// reloadFavorites relit les favoris (modifiés depuis l'application graphique par exemple)
+func (m *model) reloadFavorites() {
+	favs, err := store.LoadFavoriteEntries()
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.favs = favs
+	m.refilter()
+	m.status = "Favoris rechargés"
+}
+
+func (m *model) toggleFavorite(a models.Artist) {
+	var err error
+	if _, ok := m.favs[a.ID]; ok {
+		err = store.RemoveFavorite(a.ID)
+		m.status = a.Name + " retiré des favoris"
+	} else {
+		_, err = store.UpdateFavorite(a.ID, func(*models.Favorite) {})
+		m.status = a.Name + " ajouté aux favoris"
+	}
+	m.afterFavoriteChange(err)
+}
+
+// setRating note un favori ; un artiste qui n'est pas favori n'est pas ajouté
+func (m *model) setRating(a models.Artist, rating int) {
+	_, err := store.EditFavorite(a.ID, func(f *models.Favorite) { f.Rating = rating })
+	if errors.Is(err, store.ErrNotFavorite) {
+		m.status = a.Name + " n'est pas un favori : f pour l'ajouter avant de le noter"
+		m.favs, _ = store.LoadFavoriteEntries()
+		return
+	}
+	m.status = fmt.Sprintf("Note de %s : %d/5", a.Name, rating)
+	m.afterFavoriteChange(err)
+}
+
+func (m *model) afterFavoriteChange(err error) {
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.favs, _ = store.LoadFavoriteEntries()
+	if m.screen == screenList {
+		m.refilter()
+	}
+}
+
+func nextSort(current filter.SortKey) filter.SortKey {
+	for i, k := range filter.SortKeys {
+		if k == current {
+			return filter.SortKeys[(i+1)%len(filter.SortKeys)]
+		}
+	}
+	return filter.SortKeys[0]
+}
+
+// parseRange lit "1970-1990", "1970-", "-1990" ou "1970" ; vide = pas de borne
+func parseRange(s string) (int, int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, 0, nil
+	}
+	lo, hi, found := strings.Cut(s, "-")
+	if !found {
+		hi = lo
+	}
+	var from, to int
+	var err error
+	if lo = strings.TrimSpace(lo); lo != "" {
+		if from, err = strconv.Atoi(lo); err != nil {
+			return 0, 0, fmt.Errorf("année invalide : %q", lo)
+		}
+	}
+	if hi = strings.TrimSpace(hi); hi != "" {
+		if to, err = strconv.Atoi(hi); err != nil {
+			return 0, 0, fmt.Errorf("année invalide : %q", hi)
+		}
+	}
+	return from, to, nil
+}
+
+func formatRange(lo, hi int) string {
+	switch {
+	case lo == 0 && hi == 0:
+		return ""
+	case hi == 0:
+		return fmt.Sprintf("%d-", lo)
+	case lo == 0:
+		return fmt.Sprintf("-%d", hi)
+	}
+	return fmt.Sprintf("%d-%d", lo, hi)
+}
+
+func parseMembers(s string) ([]int, error) {
+	var members []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSuffix(strings.TrimSpace(part), "+")
+		if part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("nombre de membres invalide : %q", part)
+		}
+		members = append(members, min(n, filter.MembersMax))
+	}
+	return members, nil
+}
+
+func formatMembers(members []int) string {
+	parts := make([]string, len(members))
+	for i, n := range members {
+		parts[i] = strconv.Itoa(n)
+		if n == filter.MembersMax {
+			parts[i] += "+"
+		}
+	}
+	return strings.Join(parts, ",")
+}
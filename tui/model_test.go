@@ -0,0 +1,218 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"groupie-tracker/catalog"
+	"groupie-tracker/filter"
+	"groupie-tracker/models"
+	"groupie-tracker/store"
+)
+
+// testModel construit un modèle sur quatre artistes, sans API ni terminal.
+// Les concerts des fiches ne sont pas chargés.
+func testModel(t *testing.T) *model {
+	t.Helper()
+	t.Setenv("GROUPIE_TRACKER_HOME", t.TempDir())
+	if _, err := store.UpdateFavorite(2, func(f *models.Favorite) { f.Rating = 4; f.Tags = []string{"pop"} }); err != nil {
+		t.Fatal(err)
+	}
+
+	cat := &catalog.Catalog{
+		Artists: []models.Artist{
+			{ID: 4, Name: "ABBA", Members: []string{"Agnetha", "Björn", "Benny", "Anni-Frid"}, CreationDate: 1972, FirstAlbum: "26-03-1973"},
+			{ID: 2, Name: "Gorillaz", Members: []string{"Damon Albarn", "Jamie Hewlett"}, CreationDate: 1998, FirstAlbum: "26-03-2001"},
+			{ID: 3, Name: "Pink Floyd", Members: []string{"Roger Waters", "David Gilmour", "Nick Mason", "Richard Wright", "Syd Barrett"}, CreationDate: 1965, FirstAlbum: "05-08-1967"},
+			{ID: 1, Name: "Queen", Members: []string{"Freddie Mercury", "Brian May", "Roger Taylor", "John Deacon"}, CreationDate: 1970, FirstAlbum: "14-12-1973"},
+		},
+		Locations: map[int][]string{1: {"london-uk"}, 2: {"north_carolina-usa"}, 3: {"london-uk", "paris-france"}, 4: {"stockholm-sweden"}},
+	}
+	m := newModel(cat)
+	m.height = 20
+	m.async = func(func() func(m *model)) {}
+	return m
+}
+
+// press envoie au modèle les touches décrites par in (voir parseKeys)
+func press(m *model, in string) bool {
+	for _, k := range parseKeys([]byte(in)) {
+		if !m.update(k) {
+			return false
+		}
+	}
+	return true
+}
+
+func resultIDs(m *model) []int {
+	ids := []int{}
+	for _, a := range m.results {
+		ids = append(ids, a.ID)
+	}
+	return ids
+}
+
+func TestUpdateList(t *testing.T) {
+	tests := []struct {
+		name   string
+		keys   string
+		want   []int
+		cursor int
+		status string
+	}{
+		{"sans filtre", "", []int{4, 2, 3, 1}, 0, ""},
+		{"navigation", "jj\x1b[Bk", []int{4, 2, 3, 1}, 2, ""},
+		{"bornes", "kkG\x1b[B", []int{4, 2, 3, 1}, 3, ""},
+		{"début", "G\x1b[H", []int{4, 2, 3, 1}, 0, ""},
+		{"recherche en direct", "/roger", []int{3, 1}, 0, ""},
+		{"recherche validée", "/que\r", []int{1}, 0, ""},
+		{"recherche gardée après Échap", "/flo\x1b", []int{3}, 0, ""},
+		{"correction de la saisie", "/queex\x7f\x7f\r", []int{1}, 0, ""},
+		{"lieu", "llondon\r", []int{3, 1}, 0, ""},
+		{"création", "c1966-1980\r", []int{4, 1}, 0, ""},
+		{"création minimale", "c1972-\r", []int{4, 2}, 0, ""},
+		{"création invalide", "cabc\r", []int{4, 2, 3, 1}, 0, `année invalide : "abc"`},
+		{"premier album", "a-1970\r", []int{3}, 0, ""},
+		{"membres", "m2,5\r", []int{2, 3}, 0, ""},
+		{"membres invalides", "m0\r", []int{4, 2, 3, 1}, 0, "nombre de membres invalide"},
+		{"favoris", "F", []int{2}, 0, ""},
+		{"note minimale", "*****", []int{}, 0, ""},
+		{"tag", "tpo", []int{2}, 0, ""},
+		{"tri, sélection suivie", "s", []int{1, 3, 2, 4}, 3, ""},
+		{"filtres effacés, tri gardé", "s/queen\rFx", []int{1, 3, 2, 4}, 0, ""},
+		{"Échap efface les filtres", "F\x1b", []int{4, 2, 3, 1}, 1, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testModel(t)
+			press(m, tt.keys)
+			if got := resultIDs(m); !slices.Equal(got, tt.want) {
+				t.Errorf("résultats %v, attendu %v", got, tt.want)
+			}
+			if m.cursor != tt.cursor {
+				t.Errorf("curseur %d, attendu %d", m.cursor, tt.cursor)
+			}
+			if !strings.Contains(m.status, tt.status) || (tt.status == "" && m.status != "") {
+				t.Errorf("statut %q, attendu %q", m.status, tt.status)
+			}
+		})
+	}
+}
+
+func TestQuit(t *testing.T) {
+	tests := map[string]bool{"q": false, "\x03": false, "/q": true, "/q\rq": false, "\rq": false, "\r\x1bx": true}
+	for keys, running := range tests {
+		if got := press(testModel(t), keys); got != running {
+			t.Errorf("%q : en cours = %v, attendu %v", keys, got, running)
+		}
+	}
+}
+
+// Le curseur suit l'artiste sélectionné quand l'ordre change, et reste à la
+// même hauteur quand il disparaît des résultats
+func TestRefilterKeepsSelection(t *testing.T) {
+	m := testModel(t)
+	press(m, "j") // Gorillaz
+	press(m, "s")
+	if a, _ := m.selected(); a.ID != 2 || m.cursor != 2 {
+		t.Errorf("sélection %s à la ligne %d après le tri", a.Name, m.cursor)
+	}
+
+	press(m, "\x1b[Hj") // Pink Floyd, deuxième du tri par nom décroissant
+	press(m, "m2,4\r")  // Pink Floyd disparaît, le curseur reste sur la deuxième ligne
+	if got := resultIDs(m); !slices.Equal(got, []int{1, 2, 4}) || m.cursor != 1 {
+		t.Errorf("résultats %v, curseur %d", got, m.cursor)
+	}
+}
+
+func TestFavorites(t *testing.T) {
+	m := testModel(t)
+	press(m, "\r") // fiche d'ABBA, qui n'est pas favori
+	if m.screen != screenDetail || m.detail.ID != 4 {
+		t.Fatalf("écran %d, fiche %d", m.screen, m.detail.ID)
+	}
+
+	// Noter un artiste qui n'est pas favori ne l'ajoute pas
+	for _, keys := range []string{"3", "0"} {
+		press(m, keys)
+		if _, isFav := m.favs[4]; isFav || !strings.Contains(m.status, "n'est pas un favori") {
+			t.Errorf("%q : favoris %v, statut %q", keys, m.favs, m.status)
+		}
+	}
+	if favs, _ := store.LoadFavoriteEntries(); len(favs) != 1 {
+		t.Errorf("favoris enregistrés : %v", favs)
+	}
+
+	press(m, "f3")
+	if fav, ok := m.favs[4]; !ok || fav.Rating != 3 || m.status != "Note de ABBA : 3/5" {
+		t.Errorf("favori %+v, statut %q", fav, m.status)
+	}
+	press(m, "0")
+	if fav, ok := m.favs[4]; !ok || fav.Rating != 0 {
+		t.Errorf("note remise à zéro : %+v, %v", fav, ok)
+	}
+
+	// De retour sur la liste, les favoris filtrés suivent
+	press(m, "\x1bF")
+	if got := resultIDs(m); !slices.Equal(got, []int{4, 2}) {
+		t.Errorf("favoris listés %v", got)
+	}
+	press(m, "f")
+	if got := resultIDs(m); !slices.Equal(got, []int{2}) || m.status != "ABBA retiré des favoris" {
+		t.Errorf("après retrait : %v, statut %q", got, m.status)
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		in      string
+		lo, hi  int
+		wantErr bool
+	}{
+		{"", 0, 0, false},
+		{" 1970-1990 ", 1970, 1990, false},
+		{"1970-", 1970, 0, false},
+		{"-1990", 0, 1990, false},
+		{"1985", 1985, 1985, false},
+		{"1970 - 1980", 1970, 1980, false},
+		{"abc", 0, 0, true},
+		{"1970-x", 0, 0, true},
+	}
+	for _, tt := range tests {
+		lo, hi, err := parseRange(tt.in)
+		if (err != nil) != tt.wantErr || lo != tt.lo || hi != tt.hi {
+			t.Errorf("parseRange(%q) = %d, %d, %v", tt.in, lo, hi, err)
+		}
+		// La saisie proposée au prochain appui relit les mêmes bornes
+		if again, hi2, _ := parseRange(formatRange(lo, hi)); !tt.wantErr && (again != lo || hi2 != hi) {
+			t.Errorf("formatRange(%d, %d) = %q", lo, hi, formatRange(lo, hi))
+		}
+	}
+}
+
+func TestParseMembers(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []int
+		text    string
+		wantErr bool
+	}{
+		{"", nil, "", false},
+		{"2, 3", []int{2, 3}, "2,3", false},
+		{"8+", []int{filter.MembersMax}, "8+", false},
+		{"12", []int{filter.MembersMax}, "8+", false},
+		{"1,,4", []int{1, 4}, "1,4", false},
+		{"0", nil, "", true},
+		{"deux", nil, "", true},
+	}
+	for _, tt := range tests {
+		got, err := parseMembers(tt.in)
+		if (err != nil) != tt.wantErr || !slices.Equal(got, tt.want) {
+			t.Errorf("parseMembers(%q) = %v, %v", tt.in, got, err)
+		}
+		if text := formatMembers(got); text != tt.text {
+			t.Errorf("formatMembers(%v) = %q, attendu %q", got, text, tt.text)
+		}
+	}
+}
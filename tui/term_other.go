@@ -0,0 +1,18 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd && !windows
+
+package tui
+
+import (
+	"errors"
+	"os"
+)
+
+var errUnsupported = errors.New("terminal non pris en charge sur ce système")
+
+func makeRaw(*os.File) (func(), error) { return nil, errUnsupported }
+
+func termSize(*os.File) (int, int, error) { return 0, 0, errUnsupported }
+
+func notifyResize(chan<- os.Signal) {}
+
+func isTerminal(*os.File) bool { return false }
@@ -0,0 +1,92 @@
+// Package tui est l'interface en mode terminal (groupie-tracker tui), pensée
+// pour les sessions SSH : liste, recherche, filtres, favoris et fiche artiste.
+// Elle partage les favoris et le filtrage avec l'application graphique.
+package tui
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"groupie-tracker/catalog"
+)
+
+// ErrNotTerminal est renvoyée quand l'entrée ou la sortie n'est pas un terminal
+var ErrNotTerminal = errors.New("le mode tui nécessite un terminal interactif")
+
+// Run charge les données puis affiche l'interface jusqu'à ce que l'utilisateur quitte
+func Run() error {
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return ErrNotTerminal
+	}
+
+	os.Stdout.WriteString("Chargement des artistes...\n")
+	cat, err := catalog.Load(true)
+	if err != nil {
+		return err
+	}
+
+	restore, err := makeRaw(os.Stdin)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	// Écran alternatif et curseur masqué, rétablis en sortant
+	os.Stdout.WriteString("\x1b[?1049h\x1b[?25l\x1b[2J")
+	defer os.Stdout.WriteString("\x1b[?25h\x1b[?1049l")
+
+	return loop(newModel(cat), os.Stdin, os.Stdout)
+}
+
+// loop lit le clavier et redessine l'écran après chaque événement
+func loop(m *model, in io.Reader, out *os.File) error {
+	keys := make(chan []key)
+	readErr := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 256)
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				readErr <- err
+				return
+			}
+			keys <- parseKeys(buf[:n])
+		}
+	}()
+
+	results := make(chan func(m *model))
+	m.async = func(load func() func(m *model)) {
+		go func() { results <- load() }()
+	}
+
+	resize := make(chan os.Signal, 1)
+	notifyResize(resize)
+
+	for {
+		m.width, m.height = 80, 24
+		if w, h, err := termSize(out); err == nil && w > 0 && h > 0 {
+			m.width, m.height = w, h
+		}
+		if _, err := out.WriteString(m.view()); err != nil {
+			return err
+		}
+
+		select {
+		case batch := <-keys:
+			for _, k := range batch {
+				if !m.update(k) {
+					return nil
+				}
+			}
+		case apply := <-results:
+			apply(m)
+		case <-resize:
+		case err := <-readErr:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"groupie-tracker/api"
+	"groupie-tracker/filter"
+	"groupie-tracker/models"
+)
+
+// Codes ANSI (couleurs proches du thème de l'application graphique)
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiDim     = "\x1b[2m"
+	ansiReverse = "\x1b[7m"
+	ansiAccent  = "\x1b[36m" // cyan
+	ansiHigh    = "\x1b[35m" // magenta
+	ansiError   = "\x1b[31m"
+)
+
+var sortLabels = map[filter.SortKey]string{
+	filter.SortNameAsc:       "nom (A-Z)",
+	filter.SortNameDesc:      "nom (Z-A)",
+	filter.SortCreationNew:   "création (récent)",
+	filter.SortCreationOld:   "création (ancien)",
+	filter.SortFirstAlbumNew: "premier album (récent)",
+	filter.SortFirstAlbumOld: "premier album (ancien)",
+}
+
+// screenBuffer accumule les lignes d'un écran, tronquées à la largeur du terminal
+type screenBuffer struct {
+	width int
+	lines []string
+}
+
+func (b *screenBuffer) add(style, text string) {
+	text = truncate(text, b.width)
+	if style != "" {
+		text = style + text + ansiReset
+	}
+	b.lines = append(b.lines, text)
+}
+
+func (b *screenBuffer) rule() {
+	b.add(ansiDim, strings.Repeat("─", b.width))
+}
+
+// truncate coupe text à width caractères
+func truncate(text string, width int) string {
+	r := []rune(text)
+	if len(r) <= width {
+		return text
+	}
+	if width <= 1 {
+		return string(r[:max(width, 0)])
+	}
+	return string(r[:width-1]) + "…"
+}
+
+// pad complète text avec des espaces jusqu'à width caractères
+func pad(text string, width int) string {
+	text = truncate(text, width)
+	return text + strings.Repeat(" ", max(width-len([]rune(text)), 0))
+}
+
+// view construit l'écran complet ; chaque ligne se termine par \r\n (terminal en mode brut)
+func (m *model) view() string {
+	b := &screenBuffer{width: max(m.width, 20)}
+	if m.screen == screenDetail {
+		m.viewDetail(b)
+	} else {
+		m.viewList(b)
+	}
+
+	// Le pied de page est toujours sur les deux dernières lignes
+	body, footer := b.lines[:len(b.lines)-2], b.lines[len(b.lines)-2:]
+	for len(body) < m.height-2 {
+		body = append(body, "")
+	}
+	body = body[:max(m.height-2, 0)]
+
+	var out strings.Builder
+	out.WriteString("\x1b[H")
+	for _, line := range append(body, footer...) {
+		out.WriteString(line)
+		out.WriteString("\x1b[K\r\n")
+	}
+	s := out.String()
+	return strings.TrimSuffix(s, "\r\n") + "\x1b[J"
+}
+
+func (m *model) viewList(b *screenBuffer) {
+	b.add(ansiBold+ansiAccent, fmt.Sprintf(" GROUPIE TRACKER   %d artiste(s)   tri : %s", len(m.results), sortLabels[m.crit.Sort]))
+	b.add("", " "+m.filterSummary())
+	b.rule()
+
+	height := m.listHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+	m.offset = min(m.offset, max(len(m.results)-height, 0))
+
+	nameWidth := max(b.width-36, 10)
+	for i := m.offset; i < len(m.results) && i < m.offset+height; i++ {
+		a := m.results[i]
+		fav, isFav := m.favs[a.ID]
+		mark := " "
+		if isFav {
+			mark = "♥"
+		}
+		line := fmt.Sprintf(" %s %s %4d  %2d membre(s)  %s", mark, pad(a.Name, nameWidth), a.CreationDate, len(a.Members), strings.Repeat("★", fav.Rating))
+		if i == m.cursor {
+			b.add(ansiReverse, pad(line, b.width))
+		} else {
+			b.add("", line)
+		}
+	}
+	if len(m.results) == 0 {
+		b.add(ansiDim, " Aucun artiste ne correspond aux filtres (x pour les effacer)")
+	}
+	for len(b.lines) < height+3 {
+		b.add("", "")
+	}
+
+	b.rule()
+	m.footer(b,
+		"↑↓ naviguer  ⏎ fiche  / chercher  f favori  F favoris seuls  s tri  q quitter",
+		"l lieu  c création  a album  m membres  t tag  * note min.  x effacer  r recharger")
+}
+
+// filterSummary décrit les filtres actifs sur une ligne
+func (m *model) filterSummary() string {
+	c := m.crit
+	var parts []string
+	if c.Search != "" {
+		parts = append(parts, fmt.Sprintf("recherche « %s »", c.Search))
+	}
+	if r := formatRange(c.MinCreation, c.MaxCreation); r != "" {
+		parts = append(parts, "création "+r)
+	}
+	if r := formatRange(c.MinAlbum, c.MaxAlbum); r != "" {
+		parts = append(parts, "album "+r)
+	}
+	if len(c.Members) > 0 {
+		parts = append(parts, "membres "+formatMembers(c.Members))
+	}
+	if c.Location != "" {
+		parts = append(parts, "lieu « "+c.Location+" »")
+	}
+	if c.FavoritesOnly {
+		parts = append(parts, "favoris")
+	}
+	if c.Tag != "" {
+		parts = append(parts, "tag « "+c.Tag+" »")
+	}
+	if c.MinRating > 0 {
+		parts = append(parts, strings.Repeat("★", c.MinRating)+"+")
+	}
+	if len(parts) == 0 {
+		return "Aucun filtre"
+	}
+	return "Filtres : " + strings.Join(parts, ", ")
+}
+
+func (m *model) viewDetail(b *screenBuffer) {
+	a := m.detail
+	fav, isFav := m.favs[a.ID]
+
+	title := " " + strings.ToUpper(a.Name)
+	if isFav {
+		title += "   ♥ favori " + strings.Repeat("★", fav.Rating)
+	}
+	b.add(ansiBold+ansiAccent, title)
+	b.add("", fmt.Sprintf(" Création : %d   Premier album : %s   %d membre(s)", a.CreationDate, a.FirstAlbum, len(a.Members)))
+	b.add("", " Membres : "+strings.Join(a.Members, ", "))
+	if isFav {
+		if !fav.AddedAt.IsZero() {
+			b.add(ansiDim, " Favori depuis le "+fav.AddedAt.Format("02/01/2006"))
+		}
+		if len(fav.Tags) > 0 {
+			b.add("", " Tags : "+strings.Join(fav.Tags, ", "))
+		}
+		if fav.Note != "" {
+			b.add("", " Note : "+strings.ReplaceAll(fav.Note, "\n", " "))
+		}
+	}
+	b.rule()
+
+	switch {
+	case m.loading:
+		b.add(ansiDim, " Chargement des concerts...")
+	case m.concertsErr != nil:
+		b.add(ansiError, " Concerts indisponibles : "+m.concertsErr.Error())
+	default:
+		b.add(ansiHigh+ansiBold, fmt.Sprintf(" CONCERTS (%d)", len(m.concerts)))
+		room := max(m.height-len(b.lines)-3, 1)
+		m.scroll = min(m.scroll, max(len(m.concerts)-room, 0))
+		for i := m.scroll; i < len(m.concerts) && i < m.scroll+room; i++ {
+			b.add("", " "+concertLine(m.concerts[i]))
+		}
+	}
+
+	for len(b.lines) < m.height-3 {
+		b.add("", "")
+	}
+	b.rule()
+	m.footer(b,
+		"↑↓ défiler  f favori  0-5 note  ⌫/Échap retour  q quitter",
+		"")
+}
+
+func concertLine(c models.Concert) string {
+	return c.Date.Format("02/01/2006") + "  " + api.LocationLabel(c.Location)
+}
+
+// footer affiche la saisie en cours ou le message d'état à la place de l'aide
+func (m *model) footer(b *screenBuffer, help1, help2 string) {
+	switch {
+	case m.prompt != nil:
+		b.add(ansiBold, " "+m.prompt.label+" : "+m.prompt.text+"█")
+		b.add(ansiDim, " ⏎ valider  Échap fermer")
+	case m.status != "":
+		b.add(ansiHigh, " "+m.status)
+		b.add(ansiDim, " "+help1)
+	default:
+		b.add(ansiDim, " "+help1)
+		b.add(ansiDim, " "+help2)
+	}
+}
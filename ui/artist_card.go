@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"groupie-tracker/images"
+	"groupie-tracker/models"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// artistCard est une cellule réutilisable de la liste (mode liste) ou de la grille.
+// Les widgets List / GridWrap ne créent que les cellules visibles et les
+// réaffectent à d'autres artistes pendant le défilement via bind.
+// Un clic ouvre la fiche ; un clic milieu ou le menu du clic droit l'ouvrent
+// dans une nouvelle fenêtre.
+type artistCard struct {
+	widget.BaseWidget
+
+	grid         bool
+	onOpen       func()
+	onOpenWindow func()
+	onSelect     func(bool)
+
+	img      *artistImage
+	name     *canvas.Text
+	gridName *widget.Label
+	info     *canvas.Text
+	favIcon  *widget.Icon
+	favStars *canvas.Text
+	btn      *widget.Button
+	compare  *widget.Check
+	current  *canvas.Rectangle // cadre de l'artiste choisi au clavier
+}
+
+func newArtistCard(grid bool) *artistCard {
+	c := &artistCard{grid: grid}
+	if grid {
+		c.img = newArtistImage(images.SizeGrid)
+	} else {
+		c.img = newArtistImage(images.SizeList)
+	}
+
+	c.name = canvas.NewText("", ColAccent)
+	c.name.TextStyle = fyne.TextStyle{Bold: true, Monospace: true}
+	c.name.TextSize = 18
+	c.gridName = widget.NewLabel("")
+	c.gridName.Alignment = fyne.TextAlignCenter
+	c.gridName.TextStyle = fyne.TextStyle{Bold: true, Monospace: true}
+	c.gridName.Truncation = fyne.TextTruncateEllipsis
+
+	c.info = canvas.NewText("", ColText)
+	c.info.TextSize = 12
+
+	c.favIcon = widget.NewIcon(theme.ConfirmIcon())
+	c.favStars = canvas.NewText("", ColHighlight)
+	c.favStars.TextSize = 20
+	c.favStars.Alignment = fyne.TextAlignCenter
+
+	c.btn = widget.NewButton("", func() {
+		if c.onOpen != nil {
+			c.onOpen()
+		}
+	})
+
+	// Case de sélection pour la comparaison ; onSelect est retiré pendant bind
+	// pour que l'affichage de l'état ne soit pas pris pour un clic
+	c.compare = widget.NewCheck("", func(checked bool) {
+		if c.onSelect != nil {
+			c.onSelect(checked)
+		}
+	})
+
+	c.current = canvas.NewRectangle(color.Transparent)
+	c.current.StrokeColor = ColAccent
+	c.current.StrokeWidth = 2
+	c.current.Hide()
+
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+func (c *artistCard) CreateRenderer() fyne.WidgetRenderer {
+	imgBox := c.img.box
+	cardBg := canvas.NewRectangle(ColCard)
+
+	if c.grid {
+		content := container.NewVBox(container.NewPadded(imgBox), c.gridName, container.NewBorder(nil, nil, c.compare, nil, c.favStars))
+		return widget.NewSimpleRenderer(container.NewStack(cardBg, container.NewPadded(content), c.current))
+	}
+
+	row := container.NewBorder(nil, nil,
+		container.NewPadded(imgBox),
+		container.NewHBox(c.favIcon, c.compare, c.btn),
+		container.NewVBox(layout.NewSpacer(), c.name, c.info, layout.NewSpacer()),
+	)
+	return widget.NewSimpleRenderer(container.NewStack(cardBg, container.NewPadded(row), c.current))
+}
+
+// setCurrent encadre la cellule quand elle porte le curseur clavier
+func (c *artistCard) setCurrent(current bool) {
+	if current {
+		c.current.StrokeColor = ColAccent
+		c.current.Show()
+	} else {
+		c.current.Hide()
+	}
+	c.current.Refresh()
+}
+
+func (c *artistCard) Tapped(*fyne.PointEvent) {
+	if c.onOpen != nil {
+		c.onOpen()
+	}
+}
+
+func (c *artistCard) TappedSecondary(e *fyne.PointEvent) {
+	if c.onOpen == nil {
+		return
+	}
+	menu := fyne.NewMenu("",
+		fyne.NewMenuItem(TR("see_btn"), c.onOpen),
+		fyne.NewMenuItem(TR("open_window"), c.onOpenWindow),
+	)
+	widget.ShowPopUpMenuAtPosition(menu, fyne.CurrentApp().Driver().CanvasForObject(c), e.AbsolutePosition)
+}
+
+func (c *artistCard) MouseDown(e *desktop.MouseEvent) {
+	if e.Button == desktop.MouseButtonTertiary && c.onOpenWindow != nil {
+		c.onOpenWindow()
+	}
+}
+
+func (c *artistCard) MouseUp(*desktop.MouseEvent) {}
+
+// bind affiche un artiste dans la cellule ; selected est l'état de sa case de comparaison
+func (c *artistCard) bind(artist models.Artist, fav models.Favorite, isFav bool, selected bool, onOpen, onOpenWindow func(), onSelect func(bool)) {
+	c.onOpen = onOpen
+	c.onOpenWindow = onOpenWindow
+	c.onSelect = nil
+	c.compare.SetChecked(selected)
+	c.onSelect = onSelect
+
+	c.name.Text = strings.ToUpper(artist.Name)
+	c.name.Refresh()
+	c.gridName.SetText(strings.ToUpper(artist.Name))
+
+	infoText := fmt.Sprintf("%d | %d %s", artist.CreationDate, len(artist.Members), TR("members"))
+	stars := ratingStars(fav.Rating)
+	if stars != "" {
+		infoText += " | " + stars
+	}
+	c.info.Text = infoText
+	c.info.Refresh()
+	c.btn.SetText(TR("see_btn"))
+	c.compare.Text = TR("compare_check")
+	c.compare.Refresh()
+
+	if isFav {
+		c.favIcon.Show()
+		if stars == "" {
+			stars = "★"
+		}
+		c.favStars.Text = stars
+	} else {
+		c.favIcon.Hide()
+		c.favStars.Text = ""
+	}
+	c.favStars.Refresh()
+
+	c.img.load(artist.Image)
+}
@@ -1,12 +1,9 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
-	"image"
 	"image/color"
-	_ "image/gif"
-	_ "image/jpeg"
-	_ "image/png"
 	"io"
 	"net/http"
 	"net/url"
@@ -15,23 +12,132 @@ import (
 	"time"
 
 	"groupie-tracker/api"
+	"groupie-tracker/images"
 	"groupie-tracker/models"
+	"groupie-tracker/store"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
-func ArtistDetail(app fyne.App, artist models.Artist, isFavorite bool, onBack func(), onToggleFavorite func(bool)) fyne.CanvasObject {
+// ArtistDetail affiche la fiche d'un artiste. catalog sert à proposer des
+// artistes similaires, ouverts avec onOpen ; un membre ouvre sa page avec
+// onOpenMember. toggleFavorite agit comme le bouton favori (raccourci F).
+// saveLayout garde la séparation choisie pour les prochaines fiches : à
+// appeler quand la fiche est quittée, quel que soit le moyen (bouton, Échap,
+// fermeture de la fenêtre). Le bouton favori suit les changements faits
+// ailleurs (autre fenêtre) jusqu'à l'appel de stop.
+func ArtistDetail(app fyne.App, artist models.Artist, catalog []models.Artist, isFavorite bool, onBack func(), onToggleFavorite func(bool), onOpen func(models.Artist), onOpenMember func(string)) (view fyne.CanvasObject, toggleFavorite, saveLayout, stop func()) {
 
 	title := canvas.NewText(strings.ToUpper(artist.Name), ColAccent)
 	title.TextSize = 32
 	title.TextStyle = fyne.TextStyle{Bold: true, Monospace: true}
 	title.Alignment = fyne.TextAlignCenter
 
+	// --- NOTES PERSONNELLES (favoris enrichis) ---
+	favEntries, _ := store.LoadFavoriteEntries()
+	favEntry := favEntries[artist.ID]
+
+	ratingSelect := widget.NewSelect([]string{TR("rating_none"), "★", "★★", "★★★", "★★★★", "★★★★★"}, nil)
+	ratingSelect.SetSelectedIndex(favEntry.Rating)
+	tagsEntry := widget.NewEntry()
+	tagsEntry.SetText(strings.Join(favEntry.Tags, ", "))
+	noteEntry := widget.NewMultiLineEntry()
+	noteEntry.SetText(favEntry.Note)
+	noteEntry.Wrapping = fyne.TextWrapWord
+	addedLbl := widget.NewLabel("")
+	savedLbl := widget.NewLabel("")
+
+	btnSaveNotes := widget.NewButtonWithIcon(TR("fav_save"), theme.DocumentSaveIcon(), func() {
+		// Seuls les champs de la fiche changent : les autres favoris, modifiés
+		// ailleurs entre-temps, sont relus sous le verrou
+		entry, err := store.EditFavorite(artist.ID, func(f *models.Favorite) {
+			f.Rating = ratingSelect.SelectedIndex()
+			f.Tags = store.SplitTags(tagsEntry.Text)
+			f.Note = strings.TrimSpace(noteEntry.Text)
+		})
+		if errors.Is(err, store.ErrNotFavorite) {
+			savedLbl.SetText(TR("fav_removed"))
+			return
+		}
+		if err != nil {
+			savedLbl.SetText(err.Error())
+			return
+		}
+		tagsEntry.SetText(strings.Join(entry.Tags, ", "))
+		savedLbl.SetText(TR("fav_saved"))
+	})
+
+	notesCard := widget.NewCard(TR("fav_notes"), "", container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem(TR("fav_rating"), ratingSelect),
+			widget.NewFormItem(TR("fav_tags"), tagsEntry),
+			widget.NewFormItem(TR("fav_note"), noteEntry),
+		),
+		addedLbl,
+		container.NewBorder(nil, nil, nil, btnSaveNotes, savedLbl),
+	))
+
+	updateNotes := func(state bool) {
+		if !state {
+			notesCard.Hide()
+			return
+		}
+		addedLbl.SetText("")
+		entries, _ := store.LoadFavoriteEntries()
+		if entry, ok := entries[artist.ID]; ok && !entry.AddedAt.IsZero() {
+			addedLbl.SetText(TR("fav_added_on") + " " + entry.AddedAt.Format("02-01-2006 15:04"))
+		}
+		savedLbl.SetText("")
+		notesCard.Show()
+	}
+
+	// --- COLLECTIONS ---
+	collections, _ := store.LoadCollections()
+	var collContent fyne.CanvasObject
+	if len(collections) == 0 {
+		collContent = widget.NewLabel(TR("coll_none"))
+	} else {
+		collNames := make([]string, 0, len(collections))
+		var inColl []string
+		for _, c := range collections {
+			collNames = append(collNames, c.Name)
+			if store.CollectionHas(c, artist.ID) {
+				inColl = append(inColl, c.Name)
+			}
+		}
+		collCheck := widget.NewCheckGroup(collNames, nil)
+		collCheck.Selected = inColl
+		saved := inColl
+		collCheck.OnChanged = func(selected []string) {
+			member := make(map[string]bool)
+			for _, name := range selected {
+				member[name] = true
+			}
+			err := store.UpdateCollections(func(cols *[]models.Collection) error {
+				for i := range *cols {
+					store.SetCollectionMember(&(*cols)[i], artist.ID, member[(*cols)[i].Name])
+				}
+				return nil
+			})
+			if err != nil {
+				// Échec : les cases reviennent à l'état sauvegardé
+				dialog.ShowError(err, windowFor(app, collCheck))
+				collCheck.Selected = saved
+				collCheck.Refresh()
+				return
+			}
+			saved = append([]string(nil), selected...)
+		}
+		collContent = collCheck
+	}
+	collCard := widget.NewCard(TR("coll_title"), "", collContent)
+
 	var favBtn *widget.Button
 	updateFavBtn := func(state bool) {
 		if state {
@@ -43,14 +149,33 @@ func ArtistDetail(app fyne.App, artist models.Artist, isFavorite bool, onBack fu
 			favBtn.SetIcon(theme.ContentAddIcon())
 			favBtn.Importance = widget.MediumImportance
 		}
+		favBtn.Refresh()
 	}
 
-	favBtn = widget.NewButton("FAV", func() {
+	toggleFavorite = func() {
 		isFavorite = !isFavorite
 		updateFavBtn(isFavorite)
 		onToggleFavorite(isFavorite)
-	})
+		updateNotes(isFavorite)
+	}
+	favBtn = widget.NewButton("FAV", toggleFavorite)
 	updateFavBtn(isFavorite)
+	updateNotes(isFavorite)
+	stop = store.OnFavoritesChanged(func() {
+		fyne.Do(func() {
+			if state := store.LoadFavorites()[artist.ID]; state != isFavorite {
+				isFavorite = state
+				updateFavBtn(isFavorite)
+				updateNotes(isFavorite)
+			}
+		})
+	})
+
+	var split *container.Split
+
+	saveLayout = func() {
+		updatePreferences(func(p *store.Preferences) { p.SplitOffset = split.Offset })
+	}
 
 	headerTop := container.NewBorder(nil, nil,
 		widget.NewButtonWithIcon(TR("back_btn"), theme.NavigateBackIcon(), onBack),
@@ -62,7 +187,7 @@ func ArtistDetail(app fyne.App, artist models.Artist, isFavorite bool, onBack fu
 
 	
 	btnCopy := widget.NewButtonWithIcon("Copier le nom", theme.ContentCopyIcon(), func() {
-    app.Driver().AllWindows()[0].Clipboard().SetContent(artist.Name)
+		windowFor(app, favBtn).Clipboard().SetContent(artist.Name)
 	})
 	buttons = append(buttons, btnCopy)
 
@@ -85,35 +210,66 @@ func ArtistDetail(app fyne.App, artist models.Artist, isFavorite bool, onBack fu
 		buttons = append(buttons, widget.NewButton("DEEZER", func() { app.OpenURL(deezerUrl) }))
 	}
 
+	btnCalendar := widget.NewButtonWithIcon(TR("btn_calendar"), theme.CalendarIcon(), nil)
+	btnGeo := widget.NewButtonWithIcon(TR("btn_geo"), theme.DownloadIcon(), nil)
+	buttons = append(buttons, btnCalendar, btnGeo)
+
 	streamingBar := container.NewGridWithColumns(len(buttons), buttons...)
 
 	// --- STATS (Traduit) ---
-	relation, err := api.FetchRelation(artist.ID)
-	concertCount := 0
-	if err == nil && relation != nil {
-		for _, dates := range relation.DatesLocations {
-			concertCount += len(dates)
-		}
+	// Les concerts arrivent en arrière-plan : la fiche s'affiche sans attendre l'API
+	var concerts []models.Concert
+	btnCalendar.Disable()
+	btnGeo.Disable()
+	exportName := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(artist.Name)), " ", "_") + "_concerts"
+	btnCalendar.OnTapped = func() {
+		saveCalendar(windowFor(app, favBtn), artist.Name, exportName, []models.Artist{artist}, map[int][]models.Concert{artist.ID: concerts})
+	}
+	btnGeo.OnTapped = func() {
+		showGeoExport(windowFor(app, favBtn), artist.Name, exportName, []models.Artist{artist}, map[int][]models.Concert{artist.ID: concerts})
 	}
 
+	concertCountCard := container.NewStack(createCyberCard(TR("concerts_cnt"), "…", theme.InfoIcon()))
 	statsGrid := container.NewGridWithColumns(2,
 		createCyberCard(TR("since"), fmt.Sprintf("%d", artist.CreationDate), theme.HistoryIcon()),
 		createCyberCard(TR("start"), artist.FirstAlbum, theme.MediaMusicIcon()),
 		createCyberCard(TR("team"), fmt.Sprintf("%d", len(artist.Members)), theme.AccountIcon()),
-		createCyberCard(TR("concerts_cnt"), fmt.Sprintf("%d", concertCount), theme.InfoIcon()),
+		concertCountCard,
 	)
 
-	membersVBox := container.NewVBox()
-	for _, m := range artist.Members {
-		membersVBox.Add(canvas.NewText(" "+m, ColText))
-	}
+	membersVBox := memberLinks(artist.Members, "", onOpenMember)
 
 	concertsTitle := canvas.NewText(TR("sat_view"), ColHighlight)
 	concertsTitle.TextSize = 16
 	concertsTitle.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
 
-	cardsContainer := container.NewVBox()
-	if err == nil && len(relation.DatesLocations) > 0 {
+	rightTop := container.NewVBox(container.NewPadded(concertsTitle))
+	cardsContainer := container.NewVBox(widget.NewLabel(TR("geo_loading")))
+
+	// showRelation remplit la fiche avec les concerts reçus (sur le fil de l'interface)
+	showRelation := func(relation *models.Relation, err error) {
+		concertCount := 0
+		if err == nil && relation != nil {
+			for _, dates := range relation.DatesLocations {
+				concertCount += len(dates)
+			}
+			concerts = api.Concerts(artist.ID, relation.DatesLocations)
+		}
+		concertCountCard.Objects = []fyne.CanvasObject{createCyberCard(TR("concerts_cnt"), fmt.Sprintf("%d", concertCount), theme.InfoIcon())}
+		concertCountCard.Refresh()
+		if len(concerts) > 0 {
+			btnCalendar.Enable()
+			btnGeo.Enable()
+		}
+		if timeline := concertTimelineCard(artist, concerts); timeline != nil {
+			rightTop.Add(timeline)
+		}
+
+		cardsContainer.RemoveAll()
+		if err != nil || relation == nil || len(relation.DatesLocations) == 0 {
+			cardsContainer.Add(widget.NewLabel(TR("no_data")))
+			return
+		}
 
 		requestIndex := 0
 
@@ -204,17 +360,21 @@ func ArtistDetail(app fyne.App, artist models.Artist, isFavorite bool, onBack fu
 			cardsContainer.Add(container.NewMax(bgRow, container.NewPadded(row)))
 			cardsContainer.Add(widget.NewSeparator())
 		}
-	} else {
-		cardsContainer.Add(widget.NewLabel(TR("no_data")))
 	}
 
-	avatar := loadDetailImage(artist.Image, 220, 220)
+	go func() {
+		relation, err := api.FetchRelation(artist.ID)
+		fyne.Do(func() { showRelation(relation, err) })
+	}()
+
+	avatar := newArtistImage(images.SizeDetail)
+	avatar.load(artist.Image)
 	imgBorder := canvas.NewRectangle(color.Transparent)
 	imgBorder.StrokeColor = ColAccent
 	imgBorder.StrokeWidth = 2
 
 	left := container.NewVBox(
-		container.NewMax(imgBorder, avatar),
+		container.NewMax(imgBorder, avatar.box),
 		widget.NewSeparator(),
 		title,
 		container.NewPadded(streamingBar),
@@ -222,21 +382,24 @@ func ArtistDetail(app fyne.App, artist models.Artist, isFavorite bool, onBack fu
 		container.NewPadded(statsGrid),
 		widget.NewSeparator(),
 		container.NewPadded(membersVBox),
+		similarArtistsCard(artist, catalog, onOpen),
+		notesCard,
+		collCard,
 	)
 
 	right := container.NewBorder(
-		container.NewPadded(concertsTitle),
+		rightTop,
 		nil, nil, nil,
 		container.NewVScroll(cardsContainer),
 	)
 
 	rightBg := canvas.NewRectangle(color.NRGBA{R: 20, G: 15, B: 30, A: 255})
 
-	split := container.NewHSplit(
+	split = container.NewHSplit(
 		container.NewVScroll(left),
 		container.NewMax(rightBg, right),
 	)
-	split.Offset = 0.35
+	split.Offset = currentPreferences().SplitOffset
 
 	mainBg := canvas.NewRectangle(ColBackground)
 
@@ -246,7 +409,7 @@ func ArtistDetail(app fyne.App, artist models.Artist, isFavorite bool, onBack fu
 		split,
 	)
 
-	return container.NewMax(mainBg, page)
+	return container.NewMax(mainBg, page), toggleFavorite, saveLayout, stop
 }
 
 func createCyberCard(title, value string, icon fyne.Resource) fyne.CanvasObject {
@@ -264,28 +427,3 @@ func createCyberCard(title, value string, icon fyne.Resource) fyne.CanvasObject
 
 	return container.NewMax(bg, container.NewPadded(content))
 }
-
-func loadDetailImage(url string, w, h float32) fyne.CanvasObject {
-	rect := canvas.NewRectangle(ColCard)
-	placeholderWrapper := container.NewGridWrap(fyne.NewSize(w, h), rect)
-
-	c := container.NewStack(placeholderWrapper)
-
-	go func() {
-		resp, err := http.Get(url)
-		if err == nil && resp.StatusCode == 200 {
-			defer resp.Body.Close()
-			imgData, _, errDec := image.Decode(resp.Body)
-			if errDec == nil {
-				fyne.Do(func() {
-					img := canvas.NewImageFromImage(imgData)
-					img.FillMode = canvas.ImageFillContain
-					img.SetMinSize(fyne.NewSize(w, h))
-					c.Objects = []fyne.CanvasObject{img}
-					c.Refresh()
-				})
-			}
-		}
-	}()
-	return c
-}
@@ -2,25 +2,23 @@ package ui
 
 import (
 	"fmt"
-	"image"
-	"image/color"
-	_ "image/gif"
-	_ "image/jpeg"
-	_ "image/png"
-	"net/http"
-	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"groupie-tracker/api"
+	"groupie-tracker/export"
+	"groupie-tracker/filter"
 	"groupie-tracker/models"
+	"groupie-tracker/store"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
@@ -32,17 +30,46 @@ const (
 	ModeGrid
 )
 
-func ArtistList(app fyne.App, win fyne.Window, artists []models.Artist) fyne.CanvasObject {
+// Délai d'attente après la dernière frappe avant de relancer le filtrage
+const filterDelay = 150 * time.Millisecond
+
+// ArtistList construit l'écran principal ; route, si elle n'est pas vide, est
+// ouverte au démarrage (voir ParseRoute)
+func ArtistList(app fyne.App, win fyne.Window, artists []models.Artist, route string) fyne.CanvasObject {
     currentMode := ModeList
+    if currentPreferences().ViewMode == "grid" {
+        currentMode = ModeGrid
+    }
 
-    localArtists := append([]models.Artist(nil), artists...)
+    var localArtists []models.Artist
+    artistLocations := make(map[int][]string)
 
-    sort.Slice(localArtists, func(i, j int) bool {
-        return strings.ToLower(localArtists[i].Name) < strings.ToLower(localArtists[j].Name)
-    })
+	// reloadArtists reconstruit la liste : artistes de l'API + groupes créés par l'utilisateur
+	reloadArtists := func() {
+		localArtists = append([]models.Artist(nil), artists...)
+		bands, _ := store.LoadLocalBands()
+		for _, b := range bands {
+			localArtists = append(localArtists, b.Artist)
+			locs := make([]string, 0, len(b.DatesLocations))
+			for city := range b.DatesLocations {
+				locs = append(locs, city)
+			}
+			artistLocations[b.Artist.ID] = locs
+		}
+		sort.Slice(localArtists, func(i, j int) bool {
+			return strings.ToLower(localArtists[i].Name) < strings.ToLower(localArtists[j].Name)
+		})
+	}
+	reloadArtists()
 
-    artistLocations := make(map[int][]string)
-    mainStack := container.NewStack()
+	// Index de recherche précalculé, reconstruit quand les artistes ou leurs lieux changent
+	var searchIndex *filter.Index
+	rebuildIndex := func() {
+		searchIndex = filter.NewIndex(localArtists, artistLocations)
+	}
+	rebuildIndex()
+
+    nav := newRouter()
     contentContainer := container.NewStack()
     bgRectangle := canvas.NewRectangle(ColBackground)
 
@@ -52,6 +79,10 @@ func ArtistList(app fyne.App, win fyne.Window, artists []models.Artist) fyne.Can
 	minCreationEntry := widget.NewEntry()
 	
 	countLabel := widget.NewLabel("") 
+	btnExportList := widget.NewButtonWithIcon("", theme.DownloadIcon(), nil)
+	btnFavCalendar := widget.NewButtonWithIcon("", theme.CalendarIcon(), func() {
+		exportFavoritesCalendar(win, localArtists)
+	})
 	
 	maxCreationEntry := widget.NewEntry()
 	minAlbumEntry := widget.NewEntry()
@@ -59,86 +90,222 @@ func ArtistList(app fyne.App, win fyne.Window, artists []models.Artist) fyne.Can
 	locationEntry := widget.NewEntry()
 	searchEntry := widget.NewEntry()
 	favOnlyCheck := widget.NewCheck("", nil)
+	tagEntry := widget.NewEntry()
+	ratingFilterSelect := widget.NewSelect(nil, nil)
+	collectionSelect := widget.NewSelect(nil, nil)
 
 	lblFav := widget.NewLabel("")
 	lblCrea := widget.NewLabel("")
 	lblAlbum := widget.NewLabel("")
 	lblMembers := widget.NewLabel("")
 	lblLoc := widget.NewLabel("")
+	lblTag := widget.NewLabel("")
+	lblRating := widget.NewLabel("")
+	lblCollection := widget.NewLabel("")
+	lblPreset := widget.NewLabel("")
 	accordionItem := widget.NewAccordionItem("", nil)
 
-	showDetails := func(artist models.Artist) {
-		favorites := LoadFavorites()
-		isFav := favorites[artist.ID]
-		detailView := ArtistDetail(app, artist, isFav, func() {
-			mainStack.Objects = mainStack.Objects[:1]
-			mainStack.Refresh()
-			refreshContent()
-		}, func(newState bool) {
-			favorites[artist.ID] = newState
-			SaveFavorites(favorites)
-			refreshContent()
+	// showDetails ouvre la fiche d'un artiste, onClosed est appelé au retour.
+	// Un artiste similaire ou la page d'un membre s'ouvrent par-dessus la fiche courante.
+	var showDetails func(artist models.Artist, onClosed func())
+	var showMember func(name string)
+	showMember = func(name string) {
+		nav.Open(memberRoute(name), MemberScreen(name, localArtists, nav.Back, showDetails, showMember), nil, nil)
+	}
+	showDetails = func(artist models.Artist, onClosed func()) {
+		detailView, toggleFavorite, saveLayout, stop := ArtistDetail(app, artist, localArtists, store.LoadFavorites()[artist.ID], nav.Back, func(newState bool) {
+			if err := saveFavorite(artist.ID, newState); err != nil {
+				dialog.ShowError(err, win)
+			}
+		}, func(similar models.Artist) {
+			showDetails(similar, func() {})
+		}, showMember)
+		nav.Open(artistRoute(artist.ID), detailView, func() {
+			saveLayout()
+			onClosed()
+		}, stop)
+		nav.SetKeys(func(e *fyne.KeyEvent) {
+			if e.Name == fyne.KeyF {
+				toggleFavorite()
+			}
 		})
-		mainStack.Add(detailView)
 	}
 
-	loadImage := func(url string, s float32) fyne.CanvasObject {
-		rect := canvas.NewRectangle(color.NRGBA{R: 40, G: 40, B: 50, A: 255})
-		rect.SetMinSize(fyne.NewSize(s, s))
-		c := container.NewMax(rect)
+	// showDetailsWindow ouvre la fiche dans une nouvelle fenêtre ; la page d'un
+	// membre s'ouvre dans la fenêtre principale
+	showDetailsWindow := func(artist models.Artist) {
+		openArtistWindow(app, artist, localArtists, func(name string) {
+			showMember(name)
+			win.RequestFocus()
+		})
+	}
 
-		go func() {
-			if strings.HasPrefix(url, "file://") || (!strings.Contains(url, "://") && url != "") {
-				path := strings.TrimPrefix(url, "file://")
-				if len(path) > 2 && path[0] == '/' && path[2] == ':' {
-					path = path[1:]
-				}
-				f, err := os.Open(path)
-				if err == nil {
-					defer f.Close()
-					imgData, _, errDec := image.Decode(f)
-					if errDec == nil {
-						fyne.Do(func() {
-							img := canvas.NewImageFromImage(imgData)
-							img.FillMode = canvas.ImageFillContain
-							img.SetMinSize(fyne.NewSize(s, s))
-							c.Objects = []fyne.CanvasObject{img}
-							c.Refresh()
-						})
-					}
-				}
-				return
+	// openRoute affiche la vue d'une route ("artist/12", "stats"...)
+	var openRoute func(route string) error
+
+	// Suggestions « parce que vous aimez … », recalculées quand les favoris
+	// changent ; les concerts du catalogue affinent le score une fois chargés
+	var catalogConcerts map[int][]models.Concert
+	suggestions := newSuggestionsBar(func(a models.Artist) { showDetails(a, refreshContent) })
+
+	// Résultats affichés : List et GridWrap ne construisent que les cellules
+	// visibles et les réutilisent pendant le défilement
+	var visibleArtists []models.Artist
+	var visibleFavorites map[int]models.Favorite
+
+	// Artistes cochés pour la comparaison, dans l'ordre de sélection
+	var compareSel []models.Artist
+	btnCompare := widget.NewButtonWithIcon("", theme.ViewRestoreIcon(), nil)
+	btnClearCompare := widget.NewButtonWithIcon("", theme.ContentClearIcon(), nil)
+	updateCompare := func() {
+		btnCompare.SetText(fmt.Sprintf(TR("compare_btn"), len(compareSel)))
+		if len(compareSel) >= 2 {
+			btnCompare.Enable()
+		} else {
+			btnCompare.Disable()
+		}
+		if len(compareSel) > 0 {
+			btnClearCompare.Show()
+		} else {
+			btnClearCompare.Hide()
+		}
+	}
+	selectForCompare := func(artist models.Artist, selected bool) {
+		compareSel = slices.DeleteFunc(compareSel, func(a models.Artist) bool { return a.ID == artist.ID })
+		if selected {
+			compareSel = append(compareSel, artist)
+		}
+		updateCompare()
+	}
+	isSelected := func(id int) bool {
+		return slices.ContainsFunc(compareSel, func(a models.Artist) bool { return a.ID == id })
+	}
+
+	// Curseur clavier dans les résultats (-1 = aucun) : les flèches le
+	// déplacent, Entrée ouvre la fiche, F bascule le favori
+	cursor := -1
+
+	bindCard := func(id int, o fyne.CanvasObject) {
+		artist := visibleArtists[id]
+		fav, isFav := visibleFavorites[artist.ID]
+		card := o.(*artistCard)
+		card.bind(artist, fav, isFav, isSelected(artist.ID),
+			func() { showDetails(artist, refreshContent) },
+			func() { showDetailsWindow(artist) },
+			func(selected bool) { selectForCompare(artist, selected) })
+		card.setCurrent(id == cursor)
+	}
+
+	// Export de la liste affichée, dans l'ordre du tri courant
+	btnExportList.OnTapped = func() {
+		exported := append([]models.Artist(nil), visibleArtists...)
+		favorites := visibleFavorites
+		// Les formats cartographiques exportent les concerts des artistes listés
+		formats := append(append([]export.Format(nil), export.Formats...), export.GeoFormats...)
+		labels := make([]string, len(formats))
+		for i, f := range formats {
+			labels[i] = f.Label()
+		}
+		tourCheck := widget.NewCheck(TR("geo_tour"), nil)
+		tourCheck.Disable()
+		formatSelect := widget.NewSelect(labels, nil)
+		formatSelect.OnChanged = func(string) {
+			if slices.Contains(export.GeoFormats, formats[max(formatSelect.SelectedIndex(), 0)]) {
+				tourCheck.Enable()
+			} else {
+				tourCheck.Disable()
 			}
-			resp, err := http.Get(url)
-			if err == nil && resp.StatusCode == 200 {
-				defer resp.Body.Close()
-				imgData, _, errDec := image.Decode(resp.Body)
-				if errDec == nil {
-					fyne.Do(func() {
-						img := canvas.NewImageFromImage(imgData)
-						img.FillMode = canvas.ImageFillContain
-						img.SetMinSize(fyne.NewSize(s, s))
-						c.Objects = []fyne.CanvasObject{img}
-						c.Refresh()
-					})
+		}
+		formatSelect.SetSelectedIndex(0)
+		dialog.ShowForm(TR("btn_export_list"), TR("export_confirm"), TR("btn_cancel"),
+			[]*widget.FormItem{
+				widget.NewFormItem(TR("export_format"), formatSelect),
+				widget.NewFormItem("", tourCheck),
+			},
+			func(ok bool) {
+				if !ok {
+					return
 				}
-			}
-		}()
-		return c
+				format := formats[max(formatSelect.SelectedIndex(), 0)]
+				if slices.Contains(export.GeoFormats, format) {
+					saveGeo(win, format, tourCheck.Checked, "Groupie Tracker", "concerts", exported, nil)
+					return
+				}
+				d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+					if err != nil || writer == nil {
+						return
+					}
+					err = export.Artists(writer, format, exported, artistLocations, favorites)
+					if cerr := writer.Close(); err == nil {
+						err = cerr
+					}
+					if err != nil {
+						dialog.ShowError(err, win)
+						return
+					}
+					dialog.ShowInformation(TR("success_title"), fmt.Sprintf(TR("export_list_msg"), len(exported)), win)
+				}, win)
+				d.SetFileName("artistes" + format.Extension())
+				d.SetFilter(storage.NewExtensionFileFilter([]string{format.Extension()}))
+				d.Show()
+			}, win)
+	}
+
+	artistListWidget := widget.NewList(
+		func() int { return len(visibleArtists) },
+		func() fyne.CanvasObject { return newArtistCard(false) },
+		bindCard,
+	)
+	artistListWidget.OnSelected = func(id widget.ListItemID) {
+		artistListWidget.Unselect(id)
+		showDetails(visibleArtists[id], refreshContent)
+	}
+
+	artistGridWidget := widget.NewGridWrap(
+		func() int { return len(visibleArtists) },
+		func() fyne.CanvasObject { return newArtistCard(true) },
+		bindCard,
+	)
+	artistGridWidget.OnSelected = func(id widget.GridWrapItemID) {
+		artistGridWidget.Unselect(id)
+		showDetails(visibleArtists[id], refreshContent)
 	}
 
 	btnSettings := widget.NewButtonWithIcon("", theme.SettingsIcon(), func() {
-		ShowSettingsModal(app, win, func() {
+		ShowSettingsModal(app, win, localArtists, func() {
+			reloadArtists()
+			rebuildIndex()
 			refreshContent()
 		})
 	})
 
-	btnAdd.OnTapped = func() {
+	btnCollections := widget.NewButtonWithIcon("", theme.FolderIcon(), func() { openRoute(RouteCollections) })
+
+	btnCompare.OnTapped = func() {
+		ids := make([]int, len(compareSel))
+		for i, a := range compareSel {
+			ids[i] = a.ID
+		}
+		openRoute(compareRoute(ids))
+	}
+	btnClearCompare.OnTapped = func() {
+		compareSel = nil
+		updateCompare()
+		artistListWidget.Refresh()
+		artistGridWidget.Refresh()
+	}
+
+	btnDashboard := widget.NewButtonWithIcon("", theme.InfoIcon(), func() { openRoute(RouteStats) })
+	btnCities := widget.NewButtonWithIcon("", theme.HomeIcon(), func() { openRoute(RouteCities) })
+	btnMemberGraph := widget.NewButtonWithIcon("", theme.AccountIcon(), func() { openRoute(RouteMembers) })
+	btnConcertCalendar := widget.NewButtonWithIcon("", theme.CalendarIcon(), func() { openRoute(RouteCalendar) })
+	btnAdd.OnTapped = func() { openRoute(RouteNewBand) }
+	btnAdd.Importance = widget.HighImportance
+
+	// newBandForm construit le formulaire de création d'un groupe
+	newBandForm := func() fyne.CanvasObject {
 		form := UserBandForm(app, win,
-			func() {
-				mainStack.Objects = mainStack.Objects[:1]
-				mainStack.Refresh()
-			},
+			nav.Back,
 			func(a models.Artist, rel map[string][]string) {
 				maxID := 0
 				for _, ar := range localArtists {
@@ -153,247 +320,446 @@ func ArtistList(app fyne.App, win fyne.Window, artists []models.Artist) fyne.Can
 				if strings.TrimSpace(a.FirstAlbum) == "" {
 					a.FirstAlbum = "01-01-2000"
 				}
-				localArtists = append(localArtists, a)
-				locs := make([]string, 0, len(rel))
-				for city := range rel {
-					locs = append(locs, city)
+				if err := store.AddLocalBand(models.LocalBand{Artist: a, DatesLocations: rel}); err != nil {
+					dialog.ShowError(err, win)
+					return
 				}
-				artistLocations[a.ID] = locs
-				mainStack.Objects = mainStack.Objects[:1]
-				mainStack.Refresh()
+				reloadArtists()
+				rebuildIndex()
+				nav.Reset() // le formulaire envoyé ne doit pas revenir avec « suivant »
 				refreshContent()
 			},
 		)
-		mainStack.Add(container.NewMax(canvas.NewRectangle(ColBackground), form))
+		return container.NewMax(canvas.NewRectangle(ColBackground), form)
+	}
+
+	openRoute = func(route string) error {
+		name, arg, err := ParseRoute(route)
+		if err != nil {
+			return err
+		}
+		byID := func(id int) (models.Artist, bool) {
+			i := slices.IndexFunc(localArtists, func(a models.Artist) bool { return a.ID == id })
+			if i < 0 {
+				return models.Artist{}, false
+			}
+			return localArtists[i], true
+		}
+		switch name {
+		case RouteArtists:
+			nav.Home()
+		case RouteArtist:
+			id, _ := strconv.Atoi(arg)
+			artist, ok := byID(id)
+			if !ok {
+				return fmt.Errorf("artiste introuvable : %d", id)
+			}
+			showDetails(artist, refreshContent)
+		case RouteMember:
+			showMember(arg)
+		case RouteCompare:
+			var selected []models.Artist
+			for _, s := range strings.Split(arg, ",") {
+				id, _ := strconv.Atoi(s)
+				if artist, ok := byID(id); ok {
+					selected = append(selected, artist)
+				}
+			}
+			if len(selected) < 2 {
+				return fmt.Errorf("comparaison impossible : il faut au moins deux artistes connus (%s)", arg)
+			}
+			view, stop := CompareScreen(selected, nav.Back)
+			nav.Open(route, view, nil, stop)
+		case RouteStats:
+			nav.Open(name, DashboardScreen(localArtists, nav.Back), nil, nil)
+		case RouteMap:
+			view, stop := MapScreen(localArtists, nav.Back)
+			nav.Open(name, view, nil, stop)
+		case RouteCities:
+			nav.Open(name, CitiesScreen(app, localArtists, artistLocations, nav.Back, showDetails, func() { openRoute(RouteMap) }), nil, nil)
+		case RouteCalendar:
+			nav.Open(name, CalendarScreen(win, localArtists, nav.Back, showDetails), nil, nil)
+		case RouteMembers:
+			nav.Open(name, MemberGraphScreen(localArtists, nav.Back, showDetails, showMember), nil, nil)
+		case RouteCollections:
+			nav.Open(name, CollectionsScreen(win, localArtists, nav.Back, showDetails), refreshContent, nil)
+		case RouteNewBand:
+			nav.Open(name, newBandForm(), nil, nil)
+		}
+		return nil
 	}
-	btnAdd.Importance = widget.HighImportance
 
 	sortOptions := []string{
 		"Nom (A-Z)", "Nom (Z-A)",
 		"Année Création (Récent)", "Année Création (Ancien)",
 		"Premier Album (Récent)", "Premier Album (Ancien)",
 	}
-	sortSelect := widget.NewSelect(sortOptions, func(s string) { refreshContent() })
+	var runFilter func()
+	sortSelect := widget.NewSelect(sortOptions, func(s string) {
+		updatePreferences(func(p *store.Preferences) { p.SortOption = s })
+		runFilter()
+	})
 	sortSelect.Selected = "Nom (A-Z)"
+	for _, opt := range sortOptions {
+		if opt == currentPreferences().SortOption {
+			sortSelect.Selected = opt
+		}
+	}
 
 	membersOptions := []string{"1", "2", "3", "4", "5", "6", "7", "8+"}
-	membersCheckGroup := widget.NewCheckGroup(membersOptions, func(s []string) { refreshContent() })
+	membersCheckGroup := widget.NewCheckGroup(membersOptions, func(s []string) { runFilter() })
 	membersCheckGroup.Horizontal = true
 
-	updateFilter := func(s string) { refreshContent() }
-	minCreationEntry.OnChanged = updateFilter
-	maxCreationEntry.OnChanged = updateFilter
-	minAlbumEntry.OnChanged = updateFilter
-	maxAlbumEntry.OnChanged = updateFilter
-	locationEntry.OnChanged = updateFilter
-	searchEntry.OnChanged = updateFilter
-	favOnlyCheck.OnChanged = func(b bool) { refreshContent() }
-
-	refreshContent = func() {
-		title.Text = TR("app_title")
-		title.Refresh()
-		btnAdd.SetText(TR("btn_create"))
-		searchEntry.SetPlaceHolder(TR("search_place"))
-		sortSelect.PlaceHolder = TR("sort_place")
-		favOnlyCheck.Text = TR("fav_only")
-		favOnlyCheck.Refresh()
-		lblFav.SetText(TR("fav_only"))
-		lblCrea.SetText(TR("creation_date"))
-		lblAlbum.SetText(TR("first_album"))
-		lblMembers.SetText(TR("members"))
-		lblLoc.SetText(TR("location"))
-		accordionItem.Title = TR("filters")
-		if accordionItem.Detail != nil {
-			accordionItem.Detail.Refresh()
+	// --- PRÉRÉGLAGES DE FILTRES ---
+	presetSelect := widget.NewSelect(nil, nil)
+
+	currentPreset := func(name string) models.FilterPreset {
+		p := models.FilterPreset{
+			Name:          name,
+			Search:        searchEntry.Text,
+			MinCreation:   minCreationEntry.Text,
+			MaxCreation:   maxCreationEntry.Text,
+			MinAlbum:      minAlbumEntry.Text,
+			MaxAlbum:      maxAlbumEntry.Text,
+			Members:       append([]string(nil), membersCheckGroup.Selected...),
+			Location:      locationEntry.Text,
+			Tag:           tagEntry.Text,
+			MinRating:     max(ratingFilterSelect.SelectedIndex(), 0),
+			FavoritesOnly: favOnlyCheck.Checked,
+			Sort:          sortSelect.Selected,
 		}
+		if collectionSelect.SelectedIndex() > 0 {
+			p.Collection = collectionSelect.Selected
+		}
+		return p
+	}
 
-		favorites := LoadFavorites()
-
-		var filtered []models.Artist
-		searchFilter := strings.ToLower(searchEntry.Text)
-		locFilter := strings.ToLower(locationEntry.Text)
-		minCreation, _ := strconv.Atoi(minCreationEntry.Text)
-		maxCreation, _ := strconv.Atoi(maxCreationEntry.Text)
-		if maxCreation == 0 {
-			maxCreation = 3000
+	applyPreset := func(p models.FilterPreset) {
+		searchEntry.SetText(p.Search)
+		minCreationEntry.SetText(p.MinCreation)
+		maxCreationEntry.SetText(p.MaxCreation)
+		minAlbumEntry.SetText(p.MinAlbum)
+		maxAlbumEntry.SetText(p.MaxAlbum)
+		membersCheckGroup.SetSelected(p.Members)
+		locationEntry.SetText(p.Location)
+		tagEntry.SetText(p.Tag)
+		ratingFilterSelect.SetSelectedIndex(p.MinRating)
+		collectionSelect.Selected = p.Collection
+		favOnlyCheck.SetChecked(p.FavoritesOnly)
+		if p.Sort != "" {
+			sortSelect.SetSelected(p.Sort)
 		}
-		minAlbum, _ := strconv.Atoi(minAlbumEntry.Text)
-		maxAlbum, _ := strconv.Atoi(maxAlbumEntry.Text)
-		if maxAlbum == 0 {
-			maxAlbum = 3000
+		runFilter()
+	}
+
+	refreshPresets := func() {
+		presets, _ := store.LoadPresets()
+		names := make([]string, 0, len(presets))
+		for _, p := range presets {
+			names = append(names, p.Name)
 		}
+		presetSelect.Options = names
+		presetSelect.Refresh()
+	}
 
-		selectedMembers := make(map[int]bool)
-		for _, s := range membersCheckGroup.Selected {
-			if s == "8+" {
-				selectedMembers[8] = true
-			} else {
-				n, _ := strconv.Atoi(s)
-				selectedMembers[n] = true
+	presetSelect.OnChanged = func(name string) {
+		presets, _ := store.LoadPresets()
+		for _, p := range presets {
+			if p.Name == name {
+				applyPreset(p)
+				return
 			}
 		}
+	}
 
-		for _, a := range localArtists {
-			if favOnlyCheck.Checked && !favorites[a.ID] {
-				continue
-			}
-			if searchFilter != "" {
-				matchName := strings.Contains(strings.ToLower(a.Name), searchFilter)
-				matchMember := false
-				for _, m := range a.Members {
-					if strings.Contains(strings.ToLower(m), searchFilter) {
-						matchMember = true
-						break
-					}
+	btnSavePreset := widget.NewButtonWithIcon("", theme.DocumentSaveIcon(), func() {
+		nameEntry := widget.NewEntry()
+		nameEntry.SetText(presetSelect.Selected)
+		dialog.ShowForm(TR("preset_save"), TR("fav_save"), TR("btn_cancel"),
+			[]*widget.FormItem{widget.NewFormItem(TR("preset_name"), nameEntry)},
+			func(ok bool) {
+				name := strings.TrimSpace(nameEntry.Text)
+				if !ok || name == "" {
+					return
 				}
-				if !matchName && !matchMember {
-					continue
+				preset := currentPreset(name)
+				err := store.UpdatePresets(func(presets *[]models.FilterPreset) error {
+					*presets = store.UpsertPreset(*presets, preset)
+					return nil
+				})
+				if err != nil {
+					dialog.ShowError(err, win)
+					return
 				}
+				refreshPresets()
+				presetSelect.Selected = name
+				presetSelect.Refresh()
+			}, win)
+	})
+
+	btnDeletePreset := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+		name := presetSelect.Selected
+		if name == "" {
+			return
+		}
+		dialog.ShowConfirm(TR("preset_delete"), TR("preset_delete")+" \""+name+"\" ?", func(ok bool) {
+			if !ok {
+				return
 			}
-			if a.CreationDate < minCreation || a.CreationDate > maxCreation {
-				continue
-			}
-			var albumYear int
-			if len(a.FirstAlbum) >= 4 {
-				yearStr := a.FirstAlbum[len(a.FirstAlbum)-4:]
-				albumYear, _ = strconv.Atoi(yearStr)
-			}
-			if albumYear != 0 && (albumYear < minAlbum || albumYear > maxAlbum) {
-				continue
+			err := store.UpdatePresets(func(presets *[]models.FilterPreset) error {
+				*presets = slices.DeleteFunc(*presets, func(p models.FilterPreset) bool { return p.Name == name })
+				return nil
+			})
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
 			}
-			if len(membersCheckGroup.Selected) > 0 {
-				count := len(a.Members)
-				match := false
-				if selectedMembers[count] || (count >= 8 && selectedMembers[8]) {
-					match = true
-				}
-				if !match {
-					continue
-				}
+			presetSelect.ClearSelected()
+			refreshPresets()
+		}, win)
+	})
+	refreshPresets()
+
+	// --- FILTRAGE ---
+	// Les critères sont lus sur le fil de l'interface, le filtrage et le tri
+	// tournent en arrière-plan sur l'index, puis le résultat est affiché via fyne.Do.
+	// filterGen permet d'ignorer un résultat devenu obsolète.
+	var collections []models.Collection
+	var filterGen int
+	var debounce *time.Timer
+	forceRebind := false
+
+	criteria := func() filter.Criteria {
+		c := filter.Criteria{
+			Search:        searchEntry.Text,
+			Location:      locationEntry.Text,
+			Tag:           tagEntry.Text,
+			MinRating:     ratingFilterSelect.SelectedIndex(), // 0 ou -1 = toutes les notes
+			FavoritesOnly: favOnlyCheck.Checked,
+			Sort:          filter.SortNameAsc,
+		}
+		c.MinCreation, _ = strconv.Atoi(minCreationEntry.Text)
+		c.MaxCreation, _ = strconv.Atoi(maxCreationEntry.Text)
+		c.MinAlbum, _ = strconv.Atoi(minAlbumEntry.Text)
+		c.MaxAlbum, _ = strconv.Atoi(maxAlbumEntry.Text)
+		for _, s := range membersCheckGroup.Selected {
+			if s == "8+" {
+				c.Members = append(c.Members, filter.MembersMax)
+			} else if n, err := strconv.Atoi(s); err == nil {
+				c.Members = append(c.Members, n)
 			}
-			if locFilter != "" {
-				locs, hasLocs := artistLocations[a.ID]
-				if !hasLocs {
-					continue
-				}
-				matchLoc := false
-				for _, l := range locs {
-					cleanLoc := strings.ReplaceAll(strings.ReplaceAll(l, "-", " "), "_", " ")
-					if strings.Contains(strings.ToLower(cleanLoc), locFilter) {
-						matchLoc = true
-						break
+		}
+		if i := sortSelect.SelectedIndex(); i >= 0 && i < len(filter.SortKeys) {
+			c.Sort = filter.SortKeys[i]
+		}
+		if collectionSelect.SelectedIndex() > 0 {
+			for _, col := range collections {
+				if col.Name == collectionSelect.Selected {
+					c.ArtistIDs = make(map[int]bool)
+					for _, id := range col.ArtistIDs {
+						c.ArtistIDs[id] = true
 					}
 				}
-				if !matchLoc {
-					continue
-				}
 			}
-			filtered = append(filtered, a)
-		}
-
-		sort.Slice(filtered, func(i, j int) bool {
-			a, b := filtered[i], filtered[j]
-			switch sortSelect.Selected {
-			case "Nom (Z-A)":
-				return strings.ToLower(a.Name) > strings.ToLower(b.Name)
-			case "Année Création (Ancien)":
-				return a.CreationDate < b.CreationDate
-			case "Année Création (Récent)":
-				return a.CreationDate > b.CreationDate
-			case "Premier Album (Ancien)":
-				da, _ := time.Parse("02-01-2006", a.FirstAlbum)
-				db, _ := time.Parse("02-01-2006", b.FirstAlbum)
-				return da.Before(db)
-			case "Premier Album (Récent)":
-				da, _ := time.Parse("02-01-2006", a.FirstAlbum)
-				db, _ := time.Parse("02-01-2006", b.FirstAlbum)
-				return da.After(db)
-			default:
-				return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		}
+		return c
+	}
+
+	// Membres dont le nom correspond à la recherche, chacun avec ses groupes
+	memberLabel := widget.NewLabel("")
+	memberLabel.TextStyle = fyne.TextStyle{Bold: true}
+	memberItems := container.NewHBox()
+	memberBar := container.NewBorder(nil, nil, memberLabel, nil, container.NewHScroll(memberItems))
+	memberBar.Hide()
+	showMembers := func(matches []filter.MemberMatch) {
+		memberItems.Objects = nil
+		for _, m := range matches[:min(len(matches), memberResultsMax)] {
+			bands := make([]string, len(m.Artists))
+			for i, a := range m.Artists {
+				bands[i] = a.Name
 			}
-		})
+			link := widget.NewHyperlink(fmt.Sprintf("%s (%s)", m.Name, strings.Join(bands, ", ")), nil)
+			link.OnTapped = func() { showMember(m.Name) }
+			memberItems.Add(link)
+		}
+		memberItems.Refresh()
+		if len(matches) == 0 {
+			memberBar.Hide()
+		} else {
+			memberBar.Show()
+		}
+	}
 
-		countText := fmt.Sprintf("%d artistes trouvés", len(filtered))
-		if len(filtered) <= 1 {
-			countText = fmt.Sprintf("%d artiste trouvé", len(filtered))
+	// showResults met à jour la liste affichée ; les cellules ne sont
+	// rafraîchies que si les artistes visibles ou leurs favoris ont changé
+	showResults := func(result []models.Artist, favorites map[int]models.Favorite) {
+		countText := fmt.Sprintf("%d artistes trouvés", len(result))
+		if len(result) <= 1 {
+			countText = fmt.Sprintf("%d artiste trouvé", len(result))
 		}
 		countLabel.SetText(countText)
+		if len(result) == 0 {
+			btnExportList.Disable()
+		} else {
+			btnExportList.Enable()
+		}
 
-		var listObj fyne.CanvasObject
-
-		if currentMode == ModeList {
-			listBox := container.NewVBox()
-			for _, artist := range filtered {
-				cardBg := canvas.NewRectangle(ColCard)
-				img := loadImage(artist.Image, 70)
-				name := canvas.NewText(strings.ToUpper(artist.Name), ColAccent)
-				name.TextStyle = fyne.TextStyle{Bold: true, Monospace: true}
-				name.TextSize = 18
-
-				txtMembres := TR("members")
-				year := canvas.NewText(fmt.Sprintf("%d | %d %s", artist.CreationDate, len(artist.Members), txtMembres), ColText)
-				year.TextSize = 12
-
-				var favIcon fyne.CanvasObject
-				if favorites[artist.ID] {
-					favIcon = widget.NewIcon(theme.ConfirmIcon())
-				} else {
-					favIcon = layout.NewSpacer()
-				}
-
-				btn := widget.NewButton(TR("see_btn"), func() { showDetails(artist) })
-
-				row := container.NewBorder(nil, nil,
-					container.NewPadded(img),
-					container.NewHBox(favIcon, btn),
-					container.NewVBox(layout.NewSpacer(), name, year, layout.NewSpacer()),
-				)
-				wrapper := container.NewMax(cardBg, container.NewPadded(row))
-				listBox.Add(wrapper)
-				listBox.Add(widget.NewSeparator())
-			}
-			listObj = container.NewVScroll(container.NewPadded(listBox))
+		changed := forceRebind || !sameResults(visibleArtists, result, visibleFavorites, favorites)
+		forceRebind = false
+		// Le curseur suit son artiste s'il est toujours affiché
+		if cursor >= 0 {
+			id := visibleArtists[cursor].ID
+			cursor = slices.IndexFunc(result, func(a models.Artist) bool { return a.ID == id })
+		}
+		visibleArtists = result
+		visibleFavorites = favorites
+		if !changed {
+			return
+		}
+		if currentMode == ModeGrid {
+			artistGridWidget.Refresh()
 		} else {
-			gridContainer := container.NewGridWithColumns(3)
-			for _, artist := range filtered {
-				cardBg := canvas.NewRectangle(ColCard)
-				img := loadImage(artist.Image, 120)
-				name := widget.NewLabel(strings.ToUpper(artist.Name))
-				name.Alignment = fyne.TextAlignCenter
-				name.TextStyle = fyne.TextStyle{Bold: true, Monospace: true}
-
-				var favInd fyne.CanvasObject
-				if favorites[artist.ID] {
-					txt := canvas.NewText("★", ColHighlight)
-					txt.TextSize = 20
-					txt.Alignment = fyne.TextAlignCenter
-					favInd = txt
-				} else {
-					favInd = layout.NewSpacer()
+			artistListWidget.Refresh()
+		}
+	}
+
+	runFilter = func() {
+		if debounce != nil {
+			debounce.Stop()
+		}
+		filterGen++
+		gen := filterGen
+		c := criteria()
+		idx := searchIndex
+		go func() {
+			favorites, _ := store.LoadFavoriteEntries()
+			result := idx.Apply(c, favorites)
+			members := idx.Members(c.Search)
+			fyne.Do(func() {
+				if gen == filterGen {
+					showResults(result, favorites)
+					showMembers(members)
 				}
-				btn := widget.NewButton("", func() { showDetails(artist) })
-				btn.Importance = widget.LowImportance
+			})
+		}()
+	}
 
-				content := container.NewVBox(container.NewPadded(img), name, favInd)
-				card := container.NewMax(cardBg, container.NewPadded(content), btn)
-				gridContainer.Add(card)
+	// scheduleFilter relance le filtrage quand la saisie s'arrête
+	scheduleFilter := func(string) {
+		if debounce != nil {
+			debounce.Stop()
+		}
+		debounce = time.AfterFunc(filterDelay, func() { fyne.Do(runFilter) })
+	}
+	minCreationEntry.OnChanged = scheduleFilter
+	maxCreationEntry.OnChanged = scheduleFilter
+	minAlbumEntry.OnChanged = scheduleFilter
+	maxAlbumEntry.OnChanged = scheduleFilter
+	locationEntry.OnChanged = scheduleFilter
+	searchEntry.OnChanged = scheduleFilter
+	tagEntry.OnChanged = scheduleFilter
+	favOnlyCheck.OnChanged = func(bool) { runFilter() }
+	ratingFilterSelect.OnChanged = func(string) { runFilter() }
+	collectionSelect.OnChanged = func(string) { runFilter() }
+
+	// refreshContent retraduit l'écran, recharge les collections et relance le filtrage
+	refreshContent = func() {
+		title.Text = TR("app_title")
+		title.Refresh()
+		btnAdd.SetText(TR("btn_create"))
+		btnExportList.SetText(TR("btn_export_list"))
+		btnFavCalendar.SetText(TR("btn_fav_calendar"))
+		updateCompare()
+		searchEntry.SetPlaceHolder(TR("search_place"))
+		sortSelect.PlaceHolder = TR("sort_place")
+		favOnlyCheck.Text = TR("fav_only")
+		favOnlyCheck.Refresh()
+		lblFav.SetText(TR("fav_only"))
+		lblCrea.SetText(TR("creation_date"))
+		lblAlbum.SetText(TR("first_album"))
+		lblMembers.SetText(TR("members"))
+		memberLabel.SetText(TR("member_results"))
+		lblLoc.SetText(TR("location"))
+		lblTag.SetText(TR("tag_filter"))
+		lblRating.SetText(TR("rating_filter"))
+		ratingIdx := ratingFilterSelect.SelectedIndex()
+		ratingFilterSelect.Options = []string{TR("rating_any"), "★+", "★★+", "★★★+", "★★★★+", "★★★★★"}
+		if ratingIdx >= 0 {
+			ratingFilterSelect.Selected = ratingFilterSelect.Options[ratingIdx]
+		}
+		ratingFilterSelect.PlaceHolder = TR("rating_any")
+		ratingFilterSelect.Refresh()
+		lblCollection.SetText(TR("coll_filter"))
+		lblPreset.SetText(TR("preset_label"))
+		presetSelect.PlaceHolder = TR("preset_place")
+
+		// Les collections peuvent avoir changé (écran Collections, fiche artiste)
+		collections, _ = store.LoadCollections()
+		selectedColl := collectionSelect.Selected
+		if collectionSelect.SelectedIndex() == 0 {
+			selectedColl = ""
+		}
+		collectionSelect.PlaceHolder = TR("coll_all")
+		collectionSelect.Options = []string{TR("coll_all")}
+		found := false
+		for _, c := range collections {
+			collectionSelect.Options = append(collectionSelect.Options, c.Name)
+			if c.Name == selectedColl {
+				found = true
 			}
-			listObj = container.NewVScroll(container.NewPadded(gridContainer))
+		}
+		if !found {
+			collectionSelect.Selected = ""
+		}
+		collectionSelect.Refresh()
+		accordionItem.Title = TR("filters")
+		if accordionItem.Detail != nil {
+			accordionItem.Detail.Refresh()
 		}
 
+		var listObj fyne.CanvasObject = artistListWidget
+		if currentMode == ModeGrid {
+			listObj = artistGridWidget
+		}
 		bgRectangle.FillColor = ColBackground
 		bgRectangle.Refresh()
-		contentContainer.Objects = []fyne.CanvasObject{listObj}
-		contentContainer.Refresh()
+		if len(contentContainer.Objects) == 0 || contentContainer.Objects[0] != listObj {
+			contentContainer.Objects = []fyne.CanvasObject{listObj}
+			contentContainer.Refresh()
+		}
+
+		// La langue ou les favoris ont pu changer : toutes les cellules sont réaffichées
+		forceRebind = true
+		runFilter()
+		suggestions.update(localArtists, catalogConcerts)
 	}
 
+	// Un favori changé dans n'importe quelle fenêtre met la liste à jour
+	store.OnFavoritesChanged(func() { fyne.Do(refreshContent) })
+
+	go func() {
+		concerts, err := loadConcerts(localArtists)
+		if err == nil {
+			fyne.Do(func() {
+				catalogConcerts = concerts
+				suggestions.update(localArtists, catalogConcerts)
+			})
+		}
+	}()
+
 	go func() {
 		locs, err := api.FetchAllLocationsMap()
 		if err == nil {
 			fyne.Do(func() {
-				artistLocations = locs
+				// On complète sans écraser les lieux des groupes locaux
+				for id, l := range locs {
+					if _, exists := artistLocations[id]; !exists {
+						artistLocations[id] = l
+					}
+				}
+				rebuildIndex()
 				if locationEntry.Text != "" {
-					refreshContent()
+					runFilter()
 				}
 			})
 		}
@@ -403,25 +769,98 @@ func ArtistList(app fyne.App, win fyne.Window, artists []models.Artist) fyne.Can
 	title.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
 
 	btnToggle := widget.NewButtonWithIcon("", theme.GridIcon(), nil)
+	if currentMode == ModeGrid {
+		btnToggle.SetIcon(theme.ListIcon())
+	}
 	btnToggle.OnTapped = func() {
+		mode := "list"
 		if currentMode == ModeList {
 			currentMode = ModeGrid
 			btnToggle.SetIcon(theme.ListIcon())
+			mode = "grid"
 		} else {
 			currentMode = ModeList
 			btnToggle.SetIcon(theme.GridIcon())
 		}
+		updatePreferences(func(p *store.Preferences) { p.ViewMode = mode })
 		refreshContent()
 	}
 
-	topControl := container.NewBorder(nil, nil, title, container.NewHBox(btnAdd, btnSettings, btnToggle), nil)
+	btnShortcuts := widget.NewButtonWithIcon("", theme.HelpIcon(), func() { showShortcuts(win) })
+
+	// moveCursor déplace le curseur clavier de delta artistes et fait défiler
+	// jusqu'à lui ; le premier appui choisit le premier artiste
+	moveCursor := func(delta int) {
+		if len(visibleArtists) == 0 {
+			return
+		}
+		old := cursor
+		if cursor < 0 {
+			cursor = 0
+		} else {
+			cursor = min(max(cursor+delta, 0), len(visibleArtists)-1)
+		}
+		if currentMode == ModeGrid {
+			if old >= 0 {
+				artistGridWidget.RefreshItem(old)
+			}
+			artistGridWidget.RefreshItem(cursor)
+			artistGridWidget.ScrollTo(cursor)
+		} else {
+			if old >= 0 {
+				artistListWidget.RefreshItem(old)
+			}
+			artistListWidget.RefreshItem(cursor)
+			artistListWidget.ScrollTo(cursor)
+		}
+	}
+
+	// listKeys : touches de la liste quand aucun champ n'a le focus
+	listKeys := func(e *fyne.KeyEvent) {
+		step := 1
+		if currentMode == ModeGrid {
+			step = max(artistGridWidget.ColumnCount(), 1)
+		}
+		switch e.Name {
+		case fyne.KeyDown:
+			moveCursor(step)
+		case fyne.KeyUp:
+			moveCursor(-step)
+		case fyne.KeyRight:
+			if currentMode == ModeGrid {
+				moveCursor(1)
+			}
+		case fyne.KeyLeft:
+			if currentMode == ModeGrid {
+				moveCursor(-1)
+			}
+		case fyne.KeyReturn, fyne.KeyEnter:
+			if cursor >= 0 {
+				showDetails(visibleArtists[cursor], refreshContent)
+			}
+		case fyne.KeyF:
+			if cursor < 0 {
+				return
+			}
+			id := visibleArtists[cursor].ID
+			if err := saveFavorite(id, !store.LoadFavorites()[id]); err != nil {
+				dialog.ShowError(err, win)
+			}
+		}
+	}
+
+	topControl := container.NewBorder(nil, nil, title, container.NewHBox(btnAdd, btnDashboard, btnCities, btnConcertCalendar, btnMemberGraph, btnCollections, btnSettings, btnToggle, btnShortcuts), nil)
 
 	filtersForm := container.NewVBox(
+		lblPreset, container.NewBorder(nil, nil, nil, container.NewHBox(btnSavePreset, btnDeletePreset), presetSelect),
 		lblFav, favOnlyCheck,
 		lblCrea, container.NewGridWithColumns(2, minCreationEntry, maxCreationEntry),
 		lblAlbum, container.NewGridWithColumns(2, minAlbumEntry, maxAlbumEntry),
 		lblMembers, membersCheckGroup,
 		lblLoc, locationEntry,
+		lblTag, tagEntry,
+		lblRating, ratingFilterSelect,
+		lblCollection, collectionSelect,
 	)
 
 	accordionItem.Detail = filtersForm
@@ -431,15 +870,66 @@ func ArtistList(app fyne.App, win fyne.Window, artists []models.Artist) fyne.Can
 	header := container.NewVBox(
 		topControl,
 		container.NewGridWithColumns(2, searchEntry, sortSelect),
-		countLabel,
+		container.NewBorder(nil, nil, nil, container.NewHBox(btnClearCompare, btnCompare, btnFavCalendar, btnExportList), countLabel),
+		memberBar,
 		accordion,
+		suggestions.box,
 		widget.NewSeparator(),
 	)
 
 	refreshContent()
 
 	pageLayout := container.NewBorder(header, nil, nil, nil, contentContainer)
-	mainStack.Add(container.NewMax(bgRectangle, pageLayout))
+	nav.setRoot(container.NewMax(bgRectangle, pageLayout))
+	nav.SetKeys(listKeys)
+	nav.onKey = func(e *fyne.KeyEvent) {
+		if e.Name == fyne.KeyF1 {
+			showShortcuts(win)
+		}
+	}
+	nav.addShortcuts(win.Canvas())
+	// La fenêtre qui se ferme quitte aussi la vue affichée
+	win.SetCloseIntercept(func() {
+		nav.Close()
+		win.Close()
+	})
 
-	return mainStack
-}
\ No newline at end of file
+	// Raccourcis Ctrl, actifs dans toutes les vues
+	addCtrlShortcut(win.Canvas(), fyne.KeyF, func() {
+		nav.Home()
+		win.Canvas().Focus(searchEntry)
+	})
+	addCtrlShortcut(win.Canvas(), fyne.KeyN, func() { openRoute(RouteNewBand) })
+	addCtrlShortcut(win.Canvas(), fyne.KeyComma, btnSettings.OnTapped)
+	addCtrlShortcut(win.Canvas(), fyne.KeyG, func() {
+		nav.Home()
+		btnToggle.OnTapped()
+	})
+	addCtrlShortcut(win.Canvas(), fyne.KeySlash, func() { showShortcuts(win) })
+	if route != "" {
+		if err := openRoute(route); err != nil {
+			dialog.ShowError(err, win)
+		}
+	}
+
+	return nav.stack
+}
+
+// sameResults indique si deux résultats affichent les mêmes artistes, dans le
+// même ordre et avec les mêmes favoris
+func sameResults(oldArtists, newArtists []models.Artist, oldFavs, newFavs map[int]models.Favorite) bool {
+	if len(oldArtists) != len(newArtists) {
+		return false
+	}
+	for i, a := range newArtists {
+		if oldArtists[i].ID != a.ID {
+			return false
+		}
+		oldFav, wasFav := oldFavs[a.ID]
+		newFav, isFav := newFavs[a.ID]
+		if wasFav != isFav || oldFav.Rating != newFav.Rating {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,196 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"groupie-tracker/api"
+	"groupie-tracker/models"
+	"groupie-tracker/stats"
+	"groupie-tracker/store"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Nombre de concerts affichés dans une case avant le lien « +n »
+const calendarVisible = 3
+
+// CalendarScreen place les concerts de tous les artistes (ou des seuls
+// favoris) sur une grille mensuelle. Un nom d'artiste ouvre sa fiche.
+func CalendarScreen(win fyne.Window, artists []models.Artist, onBack func(), onOpenArtist func(artist models.Artist, onClosed func())) fyne.CanvasObject {
+	byID := make(map[int]models.Artist, len(artists))
+	for _, a := range artists {
+		byID[a.ID] = a
+	}
+	months := strings.Split(TR("cal_months"), ",")
+	weekdays := strings.Split(TR("cal_days"), ",")
+
+	title := canvas.NewText(TR("cal_title"), ColAccent)
+	title.TextSize = 20
+	title.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
+
+	now := time.Now()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	var concerts map[int][]models.Concert
+	var days map[time.Time][]stats.Event
+
+	monthLabel := canvas.NewText("", ColAccent)
+	monthLabel.TextSize = 18
+	monthLabel.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
+	countLabel := widget.NewLabel("")
+	status := widget.NewLabel(TR("geo_loading"))
+	status.Wrapping = fyne.TextWrapWord
+	favOnly := widget.NewCheck(TR("fav_only"), nil)
+	grid := container.NewGridWithColumns(7)
+	weekdayRow := container.NewGridWithColumns(7)
+	for _, name := range weekdays {
+		lbl := canvas.NewText(name, ColHighlight)
+		lbl.Alignment = fyne.TextAlignCenter
+		lbl.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
+		weekdayRow.Add(lbl)
+	}
+
+	open := func(id int) {
+		if a, ok := byID[id]; ok {
+			onOpenArtist(a, func() {})
+		}
+	}
+
+	// showDay liste tous les concerts d'un jour dans une fenêtre
+	showDay := func(day time.Time, events []stats.Event) {
+		var d dialog.Dialog
+		list := container.NewVBox()
+		for _, e := range events {
+			link := widget.NewHyperlink(byID[e.ArtistID].Name, nil)
+			link.OnTapped = func() {
+				d.Hide()
+				open(e.ArtistID)
+			}
+			list.Add(container.NewBorder(nil, nil, link, nil, widget.NewLabel(api.LocationLabel(e.Concert.Location))))
+		}
+		d = dialog.NewCustom(day.Format("02/01/2006"), TR("btn_close"), container.NewVScroll(list), win)
+		d.Resize(fyne.NewSize(420, 360))
+		d.Show()
+	}
+
+	cell := func(day time.Time) fyne.CanvasObject {
+		events := days[day]
+		num := canvas.NewText(strconv.Itoa(day.Day()), ColText)
+		num.TextStyle = fyne.TextStyle{Bold: true}
+		box := container.NewVBox(num)
+		for _, e := range events[:min(len(events), calendarVisible)] {
+			link := widget.NewHyperlink(byID[e.ArtistID].Name, nil)
+			link.Truncation = fyne.TextTruncateEllipsis
+			link.OnTapped = func() { open(e.ArtistID) }
+			box.Add(link)
+		}
+		if len(events) > calendarVisible {
+			box.Add(widget.NewButton(fmt.Sprintf(TR("cal_more"), len(events)-calendarVisible), func() { showDay(day, events) }))
+		}
+		bg := canvas.NewRectangle(ColCard)
+		if len(events) > 0 {
+			bg.StrokeColor = ColAccent
+			bg.StrokeWidth = 1
+		}
+		return container.NewMax(bg, container.NewPadded(box))
+	}
+
+	render := func() {
+		monthLabel.Text = fmt.Sprintf("%s %d", months[month.Month()-1], month.Year())
+		monthLabel.Refresh()
+
+		grid.Objects = nil
+		// La semaine commence le lundi
+		for range (int(month.Weekday()) + 6) % 7 {
+			grid.Add(canvas.NewRectangle(ColBackground))
+		}
+		count := 0
+		for day := month; day.Month() == month.Month(); day = day.AddDate(0, 0, 1) {
+			count += len(days[day])
+			grid.Add(cell(day))
+		}
+		for len(grid.Objects)%7 != 0 {
+			grid.Add(canvas.NewRectangle(ColBackground))
+		}
+		grid.Refresh()
+		countLabel.SetText(fmt.Sprintf(TR("cal_count"), count))
+	}
+
+	// filter garde les favoris si demandé, puis regroupe les concerts par jour
+	filter := func() {
+		shown := concerts
+		if favOnly.Checked {
+			favorites := store.LoadFavorites()
+			shown = make(map[int][]models.Concert)
+			for id, list := range concerts {
+				if favorites[id] {
+					shown[id] = list
+				}
+			}
+		}
+		days = stats.ByDay(shown)
+		render()
+	}
+	favOnly.OnChanged = func(bool) { filter() }
+
+	move := func(years, monthsDelta int) func() {
+		return func() {
+			month = month.AddDate(years, monthsDelta, 0)
+			render()
+		}
+	}
+	nav := container.NewHBox(
+		widget.NewButtonWithIcon("", theme.MediaFastRewindIcon(), move(-1, 0)),
+		widget.NewButtonWithIcon("", theme.NavigateBackIcon(), move(0, -1)),
+		container.NewCenter(monthLabel),
+		widget.NewButtonWithIcon("", theme.NavigateNextIcon(), move(0, 1)),
+		widget.NewButtonWithIcon("", theme.MediaFastForwardIcon(), move(1, 0)),
+	)
+	render()
+
+	go func() {
+		loaded, err := loadConcerts(artists)
+		fyne.Do(func() {
+			if err != nil {
+				status.SetText(err.Error())
+				return
+			}
+			status.Hide()
+			concerts = loaded
+			// On ouvre sur le mois du concert le plus récent
+			var last time.Time
+			for _, list := range concerts {
+				for _, c := range list {
+					if c.Date.After(last) {
+						last = c.Date
+					}
+				}
+			}
+			if !last.IsZero() {
+				month = time.Date(last.Year(), last.Month(), 1, 0, 0, 0, 0, time.UTC)
+			}
+			filter()
+		})
+	}()
+
+	header := container.NewVBox(
+		container.NewBorder(nil, nil,
+			widget.NewButtonWithIcon(TR("back_btn"), theme.NavigateBackIcon(), onBack),
+			favOnly,
+			container.NewCenter(title),
+		),
+		container.NewBorder(nil, nil, nav, countLabel),
+		status,
+		widget.NewSeparator(),
+		container.NewPadded(weekdayRow),
+	)
+
+	return container.NewMax(canvas.NewRectangle(ColBackground), container.NewBorder(header, nil, nil, nil, container.NewVScroll(container.NewPadded(grid))))
+}
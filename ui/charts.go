@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"groupie-tracker/stats"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Largeur réservée aux libellés, pour aligner les barres d'un même graphique
+const chartLabelWidth = 140
+
+// barLayout donne à son objet une fraction de la largeur disponible
+type barLayout struct {
+	fraction float32
+}
+
+func (l barLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	for _, o := range objects {
+		o.Move(fyne.NewPos(0, 0))
+		o.Resize(fyne.NewSize(size.Width*l.fraction, size.Height))
+	}
+}
+
+func (l barLayout) MinSize([]fyne.CanvasObject) fyne.Size {
+	return fyne.NewSize(0, 12)
+}
+
+func newBar(value, maxValue int, col color.Color) fyne.CanvasObject {
+	fraction := float32(0)
+	if maxValue > 0 {
+		fraction = float32(value) / float32(maxValue)
+	}
+	rect := canvas.NewRectangle(col)
+	rect.CornerRadius = 2
+	return container.New(barLayout{fraction: fraction}, rect)
+}
+
+func chartLabel(text string) fyne.CanvasObject {
+	lbl := canvas.NewText(text, ColText)
+	lbl.TextSize = 12
+	return container.NewGridWrap(fyne.NewSize(chartLabelWidth, 18), lbl)
+}
+
+func chartValue(value int) fyne.CanvasObject {
+	txt := canvas.NewText(fmt.Sprintf("%d", value), ColAccent)
+	txt.TextSize = 12
+	txt.TextStyle = fyne.TextStyle{Monospace: true}
+	return txt
+}
+
+// chartCard encadre un graphique avec son titre
+func chartCard(title string, body fyne.CanvasObject) fyne.CanvasObject {
+	t := canvas.NewText(title, ColHighlight)
+	t.TextSize = 14
+	t.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
+	return container.NewMax(canvas.NewRectangle(ColCard), container.NewPadded(container.NewBorder(t, nil, nil, nil, body)))
+}
+
+// barChart dessine un histogramme horizontal : une ligne par catégorie
+func barChart(title string, buckets []stats.Bucket) fyne.CanvasObject {
+	maxValue := 0
+	for _, b := range buckets {
+		maxValue = max(maxValue, b.Count)
+	}
+	rows := container.NewVBox()
+	for _, b := range buckets {
+		rows.Add(container.NewBorder(nil, nil, chartLabel(b.Label), chartValue(b.Count), newBar(b.Count, maxValue, ColAccent)))
+	}
+	if len(buckets) == 0 {
+		rows.Add(widget.NewLabel(TR("stats_empty")))
+	}
+	return chartCard(title, rows)
+}
+
+// pairChart compare deux séries par catégorie (catalogue en cyan, favoris en rose)
+func pairChart(title string, pairs []stats.Pair) fyne.CanvasObject {
+	maxValue := 0
+	for _, p := range pairs {
+		maxValue = max(maxValue, p.Catalog, p.Favorites)
+	}
+	rows := container.NewVBox()
+	for _, p := range pairs {
+		bars := container.NewVBox(
+			container.NewBorder(nil, nil, nil, chartValue(p.Catalog), newBar(p.Catalog, maxValue, ColAccent)),
+			container.NewBorder(nil, nil, nil, chartValue(p.Favorites), newBar(p.Favorites, maxValue, ColHighlight)),
+		)
+		rows.Add(container.NewBorder(nil, nil, chartLabel(p.Label), nil, bars))
+	}
+
+	catalog := canvas.NewText("■ "+TR("stats_catalog"), ColAccent)
+	catalog.TextSize = 12
+	favorites := canvas.NewText("■ "+TR("stats_favorites"), ColHighlight)
+	favorites.TextSize = 12
+	return chartCard(title, container.NewBorder(nil, container.NewHBox(catalog, favorites), nil, nil, rows))
+}
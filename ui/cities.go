@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"groupie-tracker/api"
+	"groupie-tracker/models"
+	"groupie-tracker/stats"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// CitiesScreen liste toutes les villes de concert avec leur nombre de
+// concerts et les artistes qui y ont joué. Les lieux des artistes sont
+// affichés tout de suite, les concerts datés complètent la liste une fois
+// les relations chargées. onOpenMap ouvre la carte de toutes les villes.
+func CitiesScreen(app fyne.App, artists []models.Artist, locations map[int][]string, onBack func(), onOpenArtist func(artist models.Artist, onClosed func()), onOpenMap func()) fyne.CanvasObject {
+	byID := make(map[int]models.Artist, len(artists))
+	for _, a := range artists {
+		byID[a.ID] = a
+	}
+
+	title := canvas.NewText(TR("cities_title"), ColAccent)
+	title.TextSize = 20
+	title.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
+
+	var all, shown []stats.City
+	var concerts map[int][]models.Concert
+	selected := ""
+	status := widget.NewLabel(TR("geo_loading"))
+	status.Wrapping = fyne.TextWrapWord
+
+	searchEntry := widget.NewEntry()
+	searchEntry.SetPlaceHolder(TR("cities_search"))
+	sortLabels := []string{TR("cities_sort_concerts"), TR("cities_sort_date"), TR("cities_sort_name")}
+	sortSelect := widget.NewSelect(sortLabels, nil)
+
+	detailBox := container.NewVBox(widget.NewLabel(TR("cities_select")))
+
+	cityList := widget.NewList(
+		func() int { return len(shown) },
+		func() fyne.CanvasObject {
+			return container.NewBorder(nil, nil, nil, widget.NewLabel(""), widget.NewLabel(""))
+		},
+		func(i widget.ListItemID, o fyne.CanvasObject) {
+			c := shown[i]
+			row := o.(*fyne.Container)
+			row.Objects[0].(*widget.Label).SetText(c.Name + ", " + c.Country)
+			row.Objects[1].(*widget.Label).SetText(fmt.Sprintf(TR("cities_row"), c.Concerts, len(c.ArtistIDs)))
+		},
+	)
+
+	showDetail := func(c stats.City) {
+		name := canvas.NewText(strings.ToUpper(c.Name), ColAccent)
+		name.TextSize = 24
+		name.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
+		country := canvas.NewText(c.Country, ColHighlight)
+		country.TextSize = 14
+
+		period := "—"
+		if !c.First.IsZero() {
+			period = c.First.Format("01/2006") + " – " + c.Last.Format("01/2006")
+		}
+		cards := container.NewGridWithColumns(3,
+			createCyberCard(TR("concerts_cnt"), fmt.Sprintf("%d", c.Concerts), theme.InfoIcon()),
+			createCyberCard(TR("stats_artists"), fmt.Sprintf("%d", len(c.ArtistIDs)), theme.AccountIcon()),
+			createCyberCard(TR("cities_period"), period, theme.HistoryIcon()),
+		)
+
+		mapURL, _ := url.Parse("https://www.openstreetmap.org/search?query=" + url.QueryEscape(api.LocationLabel(c.Location)))
+		btnMap := widget.NewButtonWithIcon(TR("cities_map"), theme.SearchIcon(), func() { app.OpenURL(mapURL) })
+
+		// Artistes de la ville, avec leurs dates de concert sur place
+		rows := container.NewVBox()
+		label := api.LocationLabel(c.Location)
+		for _, id := range c.ArtistIDs {
+			artist, known := byID[id]
+			if !known {
+				continue
+			}
+			var dates []string
+			for _, con := range concerts[id] {
+				if api.LocationLabel(con.Location) == label {
+					dates = append(dates, con.Date.Format("02/01/2006"))
+				}
+			}
+			lbl := canvas.NewText(strings.ToUpper(artist.Name), ColAccent)
+			lbl.TextStyle = fyne.TextStyle{Bold: true, Monospace: true}
+			datesLbl := widget.NewLabel(strings.Join(dates, ", "))
+			datesLbl.Wrapping = fyne.TextWrapWord
+			btnSee := widget.NewButton(TR("see_btn"), func() { onOpenArtist(artist, func() {}) })
+			row := container.NewBorder(nil, nil, nil, btnSee, container.NewVBox(lbl, datesLbl))
+			rows.Add(container.NewMax(canvas.NewRectangle(ColCard), container.NewPadded(row)))
+		}
+
+		detailBox.Objects = []fyne.CanvasObject{
+			container.NewBorder(nil, nil, nil, btnMap, container.NewVBox(name, country)),
+			cards,
+			widget.NewSeparator(),
+			rows,
+		}
+		detailBox.Refresh()
+	}
+
+	// refresh applique la recherche et le tri, en gardant la ville choisie
+	refresh := func() {
+		stats.SortCities(all, stats.CitySorts[max(sortSelect.SelectedIndex(), 0)])
+		query := strings.ToLower(strings.TrimSpace(searchEntry.Text))
+		shown = shown[:0]
+		for _, c := range all {
+			if query == "" || strings.Contains(strings.ToLower(c.Name+", "+c.Country), query) {
+				shown = append(shown, c)
+			}
+		}
+		cityList.UnselectAll()
+		cityList.Refresh()
+		for i, c := range shown {
+			if c.Location == selected {
+				cityList.Select(i) // réaffiche le détail, complété par les concerts
+			}
+		}
+	}
+
+	cityList.OnSelected = func(i widget.ListItemID) {
+		selected = shown[i].Location
+		showDetail(shown[i])
+	}
+	searchEntry.OnChanged = func(string) { refresh() }
+	sortSelect.OnChanged = func(string) { refresh() }
+	sortSelect.SetSelectedIndex(0)
+
+	all = stats.Cities(locations, nil)
+	refresh()
+
+	go func() {
+		loaded, err := loadConcerts(artists)
+		fyne.Do(func() {
+			if err != nil {
+				status.SetText(err.Error())
+				return
+			}
+			status.Hide()
+			concerts = loaded
+			all = stats.Cities(locations, concerts)
+			refresh()
+		})
+	}()
+
+	left := container.NewBorder(
+		container.NewVBox(searchEntry, sortSelect, status, widget.NewSeparator()),
+		nil, nil, nil,
+		cityList,
+	)
+	split := container.NewHSplit(left, container.NewVScroll(container.NewPadded(detailBox)))
+	split.Offset = 0.35
+
+	header := container.NewVBox(
+		container.NewBorder(nil, nil,
+			widget.NewButtonWithIcon(TR("back_btn"), theme.NavigateBackIcon(), onBack),
+			widget.NewButtonWithIcon(TR("map_title"), theme.SearchIcon(), onOpenMap),
+			container.NewCenter(title),
+		),
+		widget.NewSeparator(),
+	)
+
+	return container.NewMax(canvas.NewRectangle(ColBackground), container.NewBorder(header, nil, nil, nil, split))
+}
@@ -0,0 +1,247 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"groupie-tracker/models"
+	"groupie-tracker/store"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// validateCollectionName refuse les noms vides ou déjà utilisés (sauf par la collection ignoreID)
+func validateCollectionName(cols []models.Collection, name string, ignoreID int) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New(TR("coll_name_empty"))
+	}
+	for _, c := range cols {
+		if c.ID != ignoreID && strings.EqualFold(c.Name, strings.TrimSpace(name)) {
+			return errors.New(TR("coll_exists"))
+		}
+	}
+	return nil
+}
+
+// moveArtist déplace artistID d'une place (delta = -1 ou 1) dans la collection
+func moveArtist(c *models.Collection, artistID, delta int) {
+	i := slices.Index(c.ArtistIDs, artistID)
+	j := i + delta
+	if i < 0 || j < 0 || j >= len(c.ArtistIDs) {
+		return
+	}
+	c.ArtistIDs[i], c.ArtistIDs[j] = c.ArtistIDs[j], c.ArtistIDs[i]
+}
+
+// CollectionsScreen permet de créer, renommer, supprimer et ordonner les collections.
+// onOpenArtist ouvre la fiche d'un artiste et rappelle onClosed au retour.
+func CollectionsScreen(win fyne.Window, artists []models.Artist, onBack func(), onOpenArtist func(artist models.Artist, onClosed func())) fyne.CanvasObject {
+	byID := make(map[int]models.Artist)
+	for _, a := range artists {
+		byID[a.ID] = a
+	}
+
+	collections, err := store.LoadCollections()
+	if err != nil {
+		dialog.ShowError(err, win)
+	}
+	selected := -1
+
+	title := canvas.NewText(TR("coll_title"), ColAccent)
+	title.TextSize = 20
+	title.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
+
+	artistsBox := container.NewVBox()
+
+	var collList *widget.List
+	var refreshArtists, reload func()
+
+	// edit applique change aux collections relues sous verrou (une modification
+	// faite depuis une autre fenêtre ou un autre processus n'est pas écrasée),
+	// puis réaffiche l'écran
+	edit := func(change func(cols []models.Collection) ([]models.Collection, error)) {
+		err := store.UpdateCollections(func(cols *[]models.Collection) error {
+			updated, err := change(*cols)
+			*cols = updated
+			return err
+		})
+		if err != nil {
+			dialog.ShowError(err, win)
+		}
+		reload()
+	}
+	// editCollection modifie la collection id, si elle existe toujours
+	editCollection := func(id int, change func(c *models.Collection)) {
+		edit(func(cols []models.Collection) ([]models.Collection, error) {
+			if c := store.CollectionByID(cols, id); c != nil {
+				change(c)
+			}
+			return cols, nil
+		})
+	}
+
+	reload = func() {
+		collections, _ = store.LoadCollections()
+		if selected >= len(collections) {
+			selected = -1
+			collList.UnselectAll()
+		}
+		collList.Refresh()
+		refreshArtists()
+	}
+
+	collList = widget.NewList(
+		func() int { return len(collections) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(i widget.ListItemID, o fyne.CanvasObject) {
+			c := collections[i]
+			o.(*widget.Label).SetText(fmt.Sprintf("%s (%d)", c.Name, len(c.ArtistIDs)))
+		},
+	)
+	collList.OnSelected = func(i widget.ListItemID) {
+		selected = i
+		refreshArtists()
+	}
+
+	refreshArtists = func() {
+		artistsBox.RemoveAll()
+		if selected < 0 || selected >= len(collections) {
+			artistsBox.Add(widget.NewLabel(TR("coll_select")))
+			return
+		}
+		c := collections[selected]
+		if len(c.ArtistIDs) == 0 {
+			artistsBox.Add(widget.NewLabel(TR("coll_empty")))
+			return
+		}
+		for pos, id := range c.ArtistIDs {
+			artist, known := byID[id]
+			name := strings.ToUpper(artist.Name)
+			if !known {
+				name = fmt.Sprintf("#%d", id)
+			}
+			lbl := canvas.NewText(fmt.Sprintf("%d. %s", pos+1, name), ColAccent)
+			lbl.TextStyle = fyne.TextStyle{Bold: true, Monospace: true}
+
+			btnUp := widget.NewButtonWithIcon("", theme.MoveUpIcon(), func() {
+				editCollection(c.ID, func(c *models.Collection) { moveArtist(c, id, -1) })
+			})
+			if pos == 0 {
+				btnUp.Disable()
+			}
+			btnDown := widget.NewButtonWithIcon("", theme.MoveDownIcon(), func() {
+				editCollection(c.ID, func(c *models.Collection) { moveArtist(c, id, 1) })
+			})
+			if pos == len(c.ArtistIDs)-1 {
+				btnDown.Disable()
+			}
+			btnRemove := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+				editCollection(c.ID, func(c *models.Collection) { store.SetCollectionMember(c, id, false) })
+			})
+			btnSee := widget.NewButton(TR("see_btn"), func() { onOpenArtist(artist, reload) })
+			if !known {
+				btnSee.Disable()
+			}
+
+			row := container.NewBorder(nil, nil, nil,
+				container.NewHBox(btnUp, btnDown, btnRemove, btnSee),
+				container.NewVBox(lbl),
+			)
+			artistsBox.Add(container.NewMax(canvas.NewRectangle(ColCard), container.NewPadded(row)))
+		}
+	}
+
+	askName := func(dlgTitle, initial string, onOK func(string)) {
+		nameEntry := widget.NewEntry()
+		nameEntry.SetText(initial)
+		dialog.ShowForm(dlgTitle, TR("fav_save"), TR("btn_cancel"),
+			[]*widget.FormItem{widget.NewFormItem(TR("coll_name"), nameEntry)},
+			func(ok bool) {
+				if ok {
+					onOK(strings.TrimSpace(nameEntry.Text))
+				}
+			}, win)
+	}
+
+	btnNew := widget.NewButtonWithIcon(TR("coll_new"), theme.ContentAddIcon(), func() {
+		askName(TR("coll_new"), "", func(name string) {
+			edit(func(cols []models.Collection) ([]models.Collection, error) {
+				if err := validateCollectionName(cols, name, 0); err != nil {
+					return cols, err
+				}
+				return append(cols, models.Collection{ID: store.NextCollectionID(cols), Name: name}), nil
+			})
+			for i, c := range collections {
+				if c.Name == name {
+					collList.Select(i)
+				}
+			}
+		})
+	})
+	btnNew.Importance = widget.HighImportance
+
+	btnRename := widget.NewButtonWithIcon(TR("coll_rename"), theme.DocumentCreateIcon(), func() {
+		if selected < 0 || selected >= len(collections) {
+			return
+		}
+		c := collections[selected]
+		askName(TR("coll_rename"), c.Name, func(name string) {
+			edit(func(cols []models.Collection) ([]models.Collection, error) {
+				if err := validateCollectionName(cols, name, c.ID); err != nil {
+					return cols, err
+				}
+				if col := store.CollectionByID(cols, c.ID); col != nil {
+					col.Name = name
+				}
+				return cols, nil
+			})
+		})
+	})
+
+	btnDelete := widget.NewButtonWithIcon(TR("coll_delete"), theme.DeleteIcon(), func() {
+		if selected < 0 || selected >= len(collections) {
+			return
+		}
+		dialog.ShowConfirm(TR("coll_delete"), TR("coll_delete")+" \""+collections[selected].Name+"\" ?", func(ok bool) {
+			if !ok {
+				return
+			}
+			id := collections[selected].ID
+			selected = -1
+			collList.UnselectAll()
+			edit(func(cols []models.Collection) ([]models.Collection, error) {
+				return slices.DeleteFunc(cols, func(c models.Collection) bool { return c.ID == id }), nil
+			})
+		}, win)
+	})
+	btnDelete.Importance = widget.DangerImportance
+
+	refreshArtists()
+
+	left := container.NewBorder(
+		container.NewVBox(btnNew, container.NewGridWithColumns(2, btnRename, btnDelete), widget.NewSeparator()),
+		nil, nil, nil,
+		collList,
+	)
+
+	split := container.NewHSplit(left, container.NewVScroll(container.NewPadded(artistsBox)))
+	split.Offset = 0.35
+
+	header := container.NewVBox(
+		container.NewBorder(nil, nil,
+			widget.NewButtonWithIcon(TR("back_btn"), theme.NavigateBackIcon(), onBack),
+			nil,
+			container.NewCenter(title),
+		),
+		widget.NewSeparator(),
+	)
+
+	return container.NewMax(canvas.NewRectangle(ColBackground), container.NewBorder(header, nil, nil, nil, split))
+}
@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"groupie-tracker/api"
+	"groupie-tracker/images"
+	"groupie-tracker/models"
+	"groupie-tracker/stats"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// CompareScreen affiche plusieurs artistes côte à côte : statistiques
+// alignées, villes communes, tournées simultanées et carte combinée. Chaque
+// artiste garde la même couleur dans toutes les sections. stop arrête le
+// géocodage de la carte quand l'écran sort de l'historique.
+func CompareScreen(artists []models.Artist, onBack func()) (view fyne.CanvasObject, stop func()) {
+	var closed atomic.Bool
+
+	colorOf := func(i int) color.Color { return timelinePalette[i%len(timelinePalette)] }
+	names := make(map[int]string, len(artists))
+	for _, a := range artists {
+		names[a.ID] = a.Name
+	}
+
+	title := canvas.NewText(TR("compare_title"), ColAccent)
+	title.TextSize = 20
+	title.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
+
+	// Une colonne par artiste, alignées ligne à ligne
+	row := func(cell func(i int, a models.Artist) fyne.CanvasObject) fyne.CanvasObject {
+		grid := container.NewGridWithColumns(len(artists))
+		for i, a := range artists {
+			grid.Add(cell(i, a))
+		}
+		return grid
+	}
+
+	heads := row(func(i int, a models.Artist) fyne.CanvasObject {
+		img := newArtistImage(images.SizeGrid)
+		img.load(a.Image)
+		name := canvas.NewText(a.Name, colorOf(i))
+		name.TextSize = 16
+		name.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
+		name.Alignment = fyne.TextAlignCenter
+		return container.NewVBox(container.NewCenter(img.box), name)
+	})
+	cards := container.NewVBox(
+		row(func(_ int, a models.Artist) fyne.CanvasObject {
+			return createCyberCard(TR("since"), fmt.Sprintf("%d", a.CreationDate), theme.HistoryIcon())
+		}),
+		row(func(_ int, a models.Artist) fyne.CanvasObject {
+			return createCyberCard(TR("start"), a.FirstAlbum, theme.MediaMusicIcon())
+		}),
+		row(func(_ int, a models.Artist) fyne.CanvasObject {
+			return createCyberCard(TR("team"), fmt.Sprintf("%d", len(a.Members)), theme.AccountIcon())
+		}),
+	)
+	concertsRow := container.NewVBox()
+	shared := container.NewVBox(widget.NewLabel(TR("geo_loading")))
+	overlaps := container.NewVBox(widget.NewLabel(TR("geo_loading")))
+
+	world := newWorldMap()
+	legend := container.NewHBox()
+	for i, a := range artists {
+		txt := canvas.NewText("● "+a.Name, colorOf(i))
+		txt.TextSize = 12
+		legend.Add(txt)
+	}
+	mapStatus := widget.NewLabel(TR("geo_loading"))
+
+	setLines := func(box *fyne.Container, lines []string) {
+		box.Objects = nil
+		if len(lines) == 0 {
+			lines = []string{TR("compare_none")}
+		}
+		for _, l := range lines {
+			lbl := widget.NewLabel(l)
+			lbl.Wrapping = fyne.TextWrapWord
+			box.Add(lbl)
+		}
+		box.Refresh()
+	}
+
+	render := func(concerts map[int][]models.Concert, loadErr error) {
+		if loadErr != nil {
+			setLines(shared, []string{loadErr.Error()})
+			setLines(overlaps, []string{loadErr.Error()})
+			mapStatus.SetText(loadErr.Error())
+			return
+		}
+		concertsRow.Objects = []fyne.CanvasObject{row(func(_ int, a models.Artist) fyne.CanvasObject {
+			return createCyberCard(TR("concerts_cnt"), fmt.Sprintf("%d", len(concerts[a.ID])), theme.InfoIcon())
+		})}
+		concertsRow.Refresh()
+
+		var lines []string
+		for _, s := range stats.SharedCities(concerts) {
+			who := make([]string, len(s.ArtistIDs))
+			for i, id := range s.ArtistIDs {
+				who[i] = names[id]
+			}
+			lines = append(lines, s.City+" — "+strings.Join(who, ", "))
+		}
+		setLines(shared, lines)
+
+		lines = nil
+		tour := func(p stats.Period) string {
+			return fmt.Sprintf(TR("compare_tour"), names[p.ArtistID], p.Start.Format("02/01/2006"), p.End.Format("02/01/2006"), p.Concerts)
+		}
+		for _, o := range stats.OverlappingTours(concerts, stats.TourGap) {
+			lines = append(lines, o.Start.Format("01/2006")+" ⇄ "+tour(o.A)+" / "+tour(o.B))
+		}
+		setLines(overlaps, lines)
+	}
+
+	// locate géocode chaque lieu une seule fois, puis pose un repère par
+	// artiste qui y a joué
+	locate := func(concerts map[int][]models.Concert) {
+		byPlace := make(map[string][]int)
+		for i, a := range artists {
+			for _, c := range concerts[a.ID] {
+				if !slices.Contains(byPlace[c.Location], i) {
+					byPlace[c.Location] = append(byPlace[c.Location], i)
+				}
+			}
+		}
+		places := make([]string, 0, len(byPlace))
+		for p := range byPlace {
+			places = append(places, p)
+		}
+		slices.Sort(places)
+
+		for n, place := range places {
+			if closed.Load() {
+				return
+			}
+			latStr, lonStr, err := api.GetCoordinates(api.LocationLabel(place))
+			lat, errLat := strconv.ParseFloat(latStr, 64)
+			lon, errLon := strconv.ParseFloat(lonStr, 64)
+			located := err == nil && errLat == nil && errLon == nil
+			fyne.Do(func() {
+				if located {
+					for shift, i := range byPlace[place] {
+						world.addMarker(lat, lon, colorOf(i), shift)
+					}
+				}
+				if n+1 == len(places) {
+					mapStatus.Hide()
+				} else {
+					mapStatus.SetText(fmt.Sprintf(TR("geo_progress"), n+1, len(places)))
+				}
+			})
+		}
+		if len(places) == 0 {
+			fyne.Do(mapStatus.Hide)
+		}
+	}
+
+	go func() {
+		concerts, err := loadConcerts(artists)
+		fyne.Do(func() { render(concerts, err) })
+		if err == nil {
+			locate(concerts)
+		}
+	}()
+
+	body := container.NewVBox(
+		heads,
+		cards,
+		concertsRow,
+		widget.NewSeparator(),
+		container.NewGridWithColumns(2,
+			chartCard(TR("compare_shared"), shared),
+			chartCard(TR("compare_overlaps"), overlaps),
+		),
+		chartCard(TR("compare_map"), container.NewVBox(container.NewCenter(world.box), container.NewCenter(legend), mapStatus)),
+	)
+
+	header := container.NewVBox(
+		container.NewBorder(nil, nil,
+			widget.NewButtonWithIcon(TR("back_btn"), theme.NavigateBackIcon(), onBack),
+			nil,
+			container.NewCenter(title),
+		),
+		widget.NewSeparator(),
+	)
+
+	view = container.NewMax(canvas.NewRectangle(ColBackground), container.NewBorder(header, nil, nil, nil, container.NewVScroll(container.NewPadded(body))))
+	return view, func() { closed.Store(true) }
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+
+	"groupie-tracker/models"
+	"groupie-tracker/stats"
+	"groupie-tracker/store"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Nombre de pays et de villes affichés dans les classements
+const dashboardTop = 10
+
+// DashboardScreen affiche les statistiques du catalogue. Les graphiques sur
+// les artistes sont immédiats ; ceux sur les concerts apparaissent une fois
+// les relations chargées en arrière-plan.
+func DashboardScreen(artists []models.Artist, onBack func()) fyne.CanvasObject {
+	title := canvas.NewText(TR("dash_title"), ColAccent)
+	title.TextSize = 20
+	title.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
+
+	body := container.NewVBox()
+
+	// render reconstruit le tableau ; concerts vaut nil tant qu'ils ne sont pas chargés
+	render := func(concerts map[int][]models.Concert, loadErr error) {
+		favs, _ := store.LoadFavoriteEntries()
+		summary := stats.Summarize(artists, concerts, favs)
+
+		concertsValue, countriesValue := "…", "…"
+		if concerts != nil {
+			concertsValue = fmt.Sprintf("%d", summary.Concerts)
+			countriesValue = fmt.Sprintf("%d", summary.Countries)
+		}
+		cards := container.NewGridWithColumns(3,
+			createCyberCard(TR("stats_artists"), fmt.Sprintf("%d", summary.Artists), theme.AccountIcon()),
+			createCyberCard(TR("stats_favorites"), fmt.Sprintf("%d", summary.Favorites), theme.ContentAddIcon()),
+			createCyberCard(TR("concerts_cnt"), concertsValue, theme.InfoIcon()),
+			createCyberCard(TR("stats_countries"), countriesValue, theme.SearchIcon()),
+			createCyberCard(TR("stats_avg_members"), fmt.Sprintf("%.1f", summary.AvgMembers), theme.AccountIcon()),
+			createCyberCard(TR("stats_avg_gap"), fmt.Sprintf("%.1f", summary.AvgAlbumGap), theme.MediaMusicIcon()),
+		)
+
+		charts := container.NewGridWithColumns(2,
+			barChart(TR("stats_decades"), stats.ByCreationDecade(artists)),
+			barChart(TR("stats_members"), stats.MemberCounts(artists)),
+			barChart(TR("stats_album_gap"), stats.AlbumGap(artists)),
+			pairChart(TR("stats_fav_vs_catalog"), stats.FavoritesByDecade(artists, favs)),
+		)
+
+		var concertCharts fyne.CanvasObject
+		switch {
+		case loadErr != nil:
+			concertCharts = widget.NewLabel(loadErr.Error())
+		case concerts == nil:
+			concertCharts = container.NewVBox(widget.NewLabel(TR("geo_loading")), widget.NewProgressBarInfinite())
+		default:
+			concertCharts = container.NewGridWithColumns(2,
+				barChart(TR("stats_years"), stats.ConcertsByYear(concerts)),
+				barChart(fmt.Sprintf(TR("stats_countries_top"), dashboardTop), stats.ConcertsByCountry(concerts, dashboardTop)),
+				barChart(fmt.Sprintf(TR("stats_cities_top"), dashboardTop), stats.TopCities(concerts, dashboardTop)),
+			)
+		}
+
+		body.Objects = []fyne.CanvasObject{cards, widget.NewSeparator(), charts, concertCharts}
+		body.Refresh()
+	}
+	render(nil, nil)
+
+	go func() {
+		concerts, err := loadConcerts(artists)
+		fyne.Do(func() { render(concerts, err) })
+	}()
+
+	header := container.NewVBox(
+		container.NewBorder(nil, nil,
+			widget.NewButtonWithIcon(TR("back_btn"), theme.NavigateBackIcon(), onBack),
+			nil,
+			container.NewCenter(title),
+		),
+		widget.NewSeparator(),
+	)
+
+	return container.NewMax(canvas.NewRectangle(ColBackground), container.NewBorder(header, nil, nil, nil, container.NewVScroll(container.NewPadded(body))))
+}
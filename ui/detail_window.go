@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"groupie-tracker/models"
+	"groupie-tracker/store"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+)
+
+// Taille d'une fenêtre de fiche artiste à l'ouverture
+const detailWindowWidth, detailWindowHeight = 1000, 700
+
+// windowFor retourne la fenêtre qui affiche obj : les boîtes de dialogue
+// d'une fiche s'ouvrent dans sa fenêtre
+func windowFor(app fyne.App, obj fyne.CanvasObject) fyne.Window {
+	windows := app.Driver().AllWindows()
+	c := app.Driver().CanvasForObject(obj)
+	for _, w := range windows {
+		if w.Canvas() == c {
+			return w
+		}
+	}
+	return windows[0]
+}
+
+// openArtistWindow ouvre la fiche d'un artiste dans sa propre fenêtre. Le
+// favori y suit les changements faits dans les autres fenêtres ; un artiste
+// similaire s'ouvre dans une nouvelle fenêtre, un membre dans la fenêtre
+// principale (onOpenMember).
+func openArtistWindow(app fyne.App, artist models.Artist, catalog []models.Artist, onOpenMember func(string)) {
+	win := app.NewWindow(artist.Name)
+	view, toggleFavorite, saveLayout, stop := ArtistDetail(app, artist, catalog, store.LoadFavorites()[artist.ID], win.Close, func(newState bool) {
+		if err := saveFavorite(artist.ID, newState); err != nil {
+			dialog.ShowError(err, win)
+		}
+	}, func(similar models.Artist) {
+		openArtistWindow(app, similar, catalog, onOpenMember)
+	}, onOpenMember)
+	win.SetOnClosed(func() {
+		saveLayout()
+		stop()
+	})
+
+	// Échap ferme la fenêtre, F bascule le favori (hors champ de saisie)
+	win.Canvas().SetOnTypedKey(func(e *fyne.KeyEvent) {
+		if win.Canvas().Overlays().Top() != nil {
+			return
+		}
+		switch e.Name {
+		case fyne.KeyEscape:
+			win.Close()
+		case fyne.KeyF:
+			toggleFavorite()
+		}
+	})
+
+	win.SetContent(view)
+	win.Resize(fyne.NewSize(detailWindowWidth, detailWindowHeight))
+	win.Show()
+}
@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"groupie-tracker/api"
+	"groupie-tracker/export"
+	"groupie-tracker/models"
+	"groupie-tracker/store"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Relations de l'API, téléchargées une seule fois pour tous les écrans et
+// toutes les fenêtres. Le verrou est gardé pendant le téléchargement : les
+// écrans ouverts en même temps attendent la même requête.
+var (
+	relationsLock sync.Mutex
+	relations     map[int]map[string][]string
+)
+
+// loadConcerts charge les concerts des artistes : relations de l'API (une
+// requête au premier appel, réessayée après une erreur), dates saisies pour
+// les groupes créés par l'utilisateur
+func loadConcerts(artists []models.Artist) (map[int][]models.Concert, error) {
+	relationsLock.Lock()
+	if relations == nil {
+		loaded, err := api.FetchAllRelations()
+		if err != nil {
+			relationsLock.Unlock()
+			return nil, err
+		}
+		relations = loaded
+	}
+	all := relations
+	relationsLock.Unlock()
+
+	// Les groupes locaux peuvent changer pendant la session : relus à chaque appel
+	local := make(map[int]map[string][]string)
+	bands, _ := store.LoadLocalBands()
+	for _, b := range bands {
+		local[b.Artist.ID] = b.DatesLocations
+	}
+	concerts := make(map[int][]models.Concert, len(artists))
+	for _, a := range artists {
+		dates, ok := local[a.ID]
+		if !ok {
+			dates = all[a.ID]
+		}
+		concerts[a.ID] = api.Concerts(a.ID, dates)
+	}
+	return concerts, nil
+}
+
+// saveCalendar demande un fichier .ics puis y écrit les concerts des artistes
+func saveCalendar(win fyne.Window, name, fileName string, artists []models.Artist, concerts map[int][]models.Concert) {
+	count := 0
+	for _, a := range artists {
+		count += len(concerts[a.ID])
+	}
+	if count == 0 {
+		dialog.ShowInformation(TR("btn_calendar"), TR("calendar_empty"), win)
+		return
+	}
+
+	d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			return
+		}
+		err = export.Calendar(writer, name, artists, concerts)
+		if cerr := writer.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		dialog.ShowInformation(TR("success_title"), fmt.Sprintf(TR("calendar_msg"), count), win)
+	}, win)
+	d.SetFileName(fileName + export.ICalendar.Extension())
+	d.SetFilter(storage.NewExtensionFileFilter([]string{export.ICalendar.Extension()}))
+	d.Show()
+}
+
+// exportFavoritesCalendar exporte les concerts de tous les favoris ; les
+// relations de l'API sont chargées en arrière-plan
+func exportFavoritesCalendar(win fyne.Window, artists []models.Artist) {
+	favorites, err := store.LoadFavoriteEntries()
+	if err != nil {
+		dialog.ShowError(err, win)
+		return
+	}
+	var favArtists []models.Artist
+	for _, a := range artists {
+		if _, ok := favorites[a.ID]; ok {
+			favArtists = append(favArtists, a)
+		}
+	}
+	if len(favArtists) == 0 {
+		dialog.ShowInformation(TR("btn_calendar"), TR("calendar_no_fav"), win)
+		return
+	}
+
+	go func() {
+		concerts, err := loadConcerts(favArtists)
+		fyne.Do(func() {
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			saveCalendar(win, TR("calendar_name"), "concerts_favoris", favArtists, concerts)
+		})
+	}()
+}
+
+// saveGeo demande le fichier, puis géocode les lieux en arrière-plan (une
+// requête par seconde au plus, avec une barre d'avancement annulable) avant
+// d'écrire le GeoJSON ou le KML. concerts peut être nil : ils sont alors chargés.
+func saveGeo(win fyne.Window, format export.Format, tour bool, name, fileName string, artists []models.Artist, concerts map[int][]models.Concert) {
+	d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			return
+		}
+
+		// Fermer la boîte d'avancement annule ; finished distingue la fermeture en fin de travail
+		var cancelled atomic.Bool
+		finished := false
+		status := widget.NewLabel(TR("geo_loading"))
+		bar := widget.NewProgressBar()
+		progress := dialog.NewCustom(TR("btn_geo"), TR("btn_cancel"), container.NewVBox(status, bar), win)
+		progress.SetOnClosed(func() {
+			if !finished {
+				cancelled.Store(true)
+			}
+		})
+		progress.Show()
+
+		go func() {
+			var err error
+			if concerts == nil {
+				concerts, err = loadConcerts(artists)
+			}
+			var points map[string]export.Point
+			var missing []string
+			if err == nil {
+				geocode := func(place string) (string, string, error) {
+					if cancelled.Load() {
+						return "", "", fmt.Errorf("annulé")
+					}
+					return api.GetCoordinates(place)
+				}
+				points, missing = export.Locate(concerts, geocode, func(done, total int) {
+					fyne.Do(func() {
+						status.SetText(fmt.Sprintf(TR("geo_progress"), done, total))
+						bar.SetValue(float64(done) / float64(total))
+					})
+				})
+				if !cancelled.Load() {
+					err = export.Geo(writer, format, name, artists, concerts, points, tour)
+				}
+			}
+			if cerr := writer.Close(); err == nil {
+				err = cerr
+			}
+
+			fyne.Do(func() {
+				if cancelled.Load() {
+					return
+				}
+				finished = true
+				progress.Hide()
+				if err != nil {
+					dialog.ShowError(err, win)
+					return
+				}
+				msg := fmt.Sprintf(TR("geo_msg"), len(points))
+				if len(missing) > 0 {
+					labels := make([]string, len(missing))
+					for i, m := range missing {
+						labels[i] = api.LocationLabel(m)
+					}
+					msg += "\n" + TR("geo_missing") + " " + strings.Join(labels, ", ")
+				}
+				dialog.ShowInformation(TR("success_title"), msg, win)
+			})
+		}()
+	}, win)
+	d.SetFileName(fileName + format.Extension())
+	d.SetFilter(storage.NewExtensionFileFilter([]string{format.Extension()}))
+	d.Show()
+}
+
+// showGeoExport propose le format cartographique et le tracé des tournées
+func showGeoExport(win fyne.Window, name, fileName string, artists []models.Artist, concerts map[int][]models.Concert) {
+	labels := make([]string, len(export.GeoFormats))
+	for i, f := range export.GeoFormats {
+		labels[i] = f.Label()
+	}
+	formatSelect := widget.NewSelect(labels, nil)
+	formatSelect.SetSelectedIndex(0)
+	tourCheck := widget.NewCheck(TR("geo_tour"), nil)
+	dialog.ShowForm(TR("btn_geo"), TR("export_confirm"), TR("btn_cancel"),
+		[]*widget.FormItem{
+			widget.NewFormItem(TR("export_format"), formatSelect),
+			widget.NewFormItem("", tourCheck),
+		},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			format := export.GeoFormats[max(formatSelect.SelectedIndex(), 0)]
+			saveGeo(win, format, tourCheck.Checked, name, fileName, artists, concerts)
+		}, win)
+}
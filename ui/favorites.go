@@ -1,52 +1,26 @@
 package ui
 
 import (
-	"encoding/json"
-	"os"
-	"sync"
-)
-
-const favFileName = "favorites.json"
-
-// Gestionnaire de favoris avec Mutex pour éviter les conflits
-var favLock sync.Mutex
+	"strings"
 
-func LoadFavorites() map[int]bool {
-	favLock.Lock()
-	defer favLock.Unlock()
-
-	favs := make(map[int]bool)
-
-	file, err := os.Open(favFileName)
-	if err != nil {
-		return favs
-	}
-	defer file.Close()
-
-	var ids []int
-	json.NewDecoder(file).Decode(&ids)
+	"groupie-tracker/models"
+	"groupie-tracker/store"
+)
 
-	for _, id := range ids {
-		favs[id] = true
+// ratingStars affiche une note sous forme d'étoiles (ex: ★★★☆☆)
+func ratingStars(rating int) string {
+	if rating <= 0 {
+		return ""
 	}
-	return favs
+	return strings.Repeat("★", rating) + strings.Repeat("☆", 5-rating)
 }
 
-// SaveFavorites sauvegarde la map des favoris sur le .json
-func SaveFavorites(favs map[int]bool) {
-	favLock.Lock()
-	defer favLock.Unlock()
-
-	var ids []int
-	for id, isFav := range favs {
-		if isFav {
-			ids = append(ids, id)
-		}
-	}
-
-	file, err := os.Create(favFileName)
-	if err == nil {
-		defer file.Close()
-		json.NewEncoder(file).Encode(ids)
+// saveFavorite ajoute ou retire un favori en relisant les favoris sauvegardés :
+// un changement fait depuis une autre fenêtre n'est pas écrasé
+func saveFavorite(id int, favorite bool) error {
+	if favorite {
+		_, err := store.UpdateFavorite(id, func(*models.Favorite) {})
+		return err
 	}
+	return store.RemoveFavorite(id)
 }
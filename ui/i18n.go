@@ -42,13 +42,147 @@ var dictionary = map[string]map[string]string{
 		"wiki_btn":     "WIKIPEDIA",
 
 		// Nouveautés Settings
-		"btn_export":    "Exporter les favoris",
-		"btn_import":    "Importer les favoris",
+		"btn_export":    "Exporter mes données",
+		"btn_import":    "Importer mes données",
 		"btn_about":     "À propos",
 		"success_title": "Succès",
-		"export_msg":    "Liste exportée avec succès !",
-		"import_msg":    "Liste importée avec succès !",
+		"export_msg":    "Sauvegarde exportée avec succès !",
+		"import_msg":    "Sauvegarde importée avec succès !",
 		"about_text":    "Groupie Tracker v2.0\nCréé par Paul, Aboubakar, Lina\nProjet Étudiant Ynov",
+
+		// Favoris enrichis
+		"fav_notes":     "Notes personnelles",
+		"fav_rating":    "Note",
+		"fav_tags":      "Tags (séparés par des virgules)",
+		"fav_note":      "Commentaire",
+		"fav_save":      "Enregistrer",
+		"fav_saved":     "Notes enregistrées !",
+		"fav_removed":   "Cet artiste n'est plus dans vos favoris : notes non enregistrées.",
+		"fav_added_on":  "Ajouté le",
+		"rating_none":   "Aucune",
+		"rating_filter": "Note minimale",
+		"rating_any":    "Toutes",
+		"tag_filter":    "Tag",
+
+		// Collections
+		"coll_title":      "COLLECTIONS",
+		"coll_new":        "Nouvelle collection",
+		"coll_rename":     "Renommer",
+		"coll_delete":     "Supprimer",
+		"coll_name":       "Nom",
+		"coll_select":     "Sélectionnez une collection",
+		"coll_empty":      "Collection vide : ajoutez des artistes depuis leur fiche",
+		"coll_none":       "Aucune collection : créez-en depuis l'écran Collections",
+		"coll_exists":     "Une collection porte déjà ce nom",
+		"coll_name_empty": "Le nom ne peut pas être vide",
+		"coll_all":        "Toutes",
+		"coll_filter":     "Collection",
+		"btn_cancel":      "Annuler",
+
+		// Sauvegardes et préréglages
+		"import_preview":       "Aperçu de l'import",
+		"import_mode":          "Mode",
+		"import_merge":         "Fusionner",
+		"import_replace":       "Remplacer",
+		"btn_apply":            "Importer",
+		"prev_line":            "%s : %d ajout(s), %d modification(s), %d suppression(s)",
+		"prev_favorites":       "Favoris",
+		"prev_local":           "Groupes locaux",
+		"prev_presets":         "Préréglages",
+		"prev_prefs":           "Les préférences seront remplacées",
+		"prev_nothing":         "Aucun changement",
+		"err_unknown_ids":      "La sauvegarde référence des artistes inconnus :",
+		"err_checksum":         "Somme de contrôle invalide : le fichier a été modifié ou tronqué.",
+		"err_backup_version":   "Cette sauvegarde provient d'une version plus récente de l'application.",
+		"err_backup_format":    "Fichier de sauvegarde non reconnu.",
+		"preset_label":         "Préréglage",
+		"preset_place":         "Charger un préréglage...",
+		"preset_save":          "Enregistrer le préréglage",
+		"preset_delete":        "Supprimer le préréglage",
+		"preset_name":          "Nom",
+		"btn_export_list":      "Exporter la liste",
+		"export_format":        "Format",
+		"export_list_msg":      "%d artiste(s) exporté(s)",
+		"btn_calendar":         "Agenda (.ics)",
+		"btn_fav_calendar":     "Concerts des favoris",
+		"calendar_empty":       "Aucun concert à exporter.",
+		"calendar_no_fav":      "Aucun favori : ajoutez des artistes à vos favoris pour exporter leurs concerts.",
+		"calendar_msg":         "%d concert(s) exporté(s)",
+		"calendar_name":        "Concerts de mes favoris",
+		"export_confirm":       "Exporter",
+		"btn_geo":              "Carte (GeoJSON / KML)",
+		"geo_tour":             "Tracer les tournées (GeoJSON / KML)",
+		"geo_loading":          "Chargement des concerts...",
+		"geo_progress":         "Géocodage des lieux : %d/%d",
+		"geo_msg":              "%d lieu(x) exporté(s).",
+		"geo_missing":          "Lieux introuvables, ignorés :",
+		"dash_title":           "STATISTIQUES",
+		"stats_empty":          "Aucune donnée",
+		"stats_artists":        "Artistes",
+		"stats_favorites":      "Favoris",
+		"stats_catalog":        "Catalogue",
+		"stats_countries":      "Pays",
+		"stats_avg_members":    "Membres (moy.)",
+		"stats_avg_gap":        "Années avant le 1er album",
+		"stats_decades":        "Groupes par décennie de création",
+		"stats_members":        "Nombre de membres",
+		"stats_album_gap":      "Années entre création et 1er album",
+		"stats_fav_vs_catalog": "Favoris / catalogue par décennie",
+		"stats_years":          "Concerts par année",
+		"stats_countries_top":  "Top %d des pays (concerts)",
+		"stats_cities_top":     "Top %d des villes (concerts)",
+		"tl_title":             "FRISE DES CONCERTS",
+		"tl_year":              "Années",
+		"tl_month":             "Mois",
+		"tl_hint":              "Survolez un point pour voir le concert",
+		"tl_creation":          "Création",
+		"tl_album":             "1er album",
+		"compare_btn":          "Comparer (%d)",
+		"compare_check":        "Comparer",
+		"compare_title":        "COMPARAISON",
+		"compare_shared":       "Villes communes",
+		"compare_overlaps":     "Tournées simultanées",
+		"compare_none":         "Aucune",
+		"compare_map":          "Carte combinée",
+		"compare_tour":         "%s : %s – %s (%d concerts)",
+		"similar_title":        "Artistes similaires",
+		"similar_sub":          "Époque, membres, villes et tournées",
+		"suggest_title":        "Suggestions :",
+		"suggest_because":      "%s (vous aimez %s)",
+		"cities_title":         "VILLES",
+		"cities_search":        "Rechercher une ville ou un pays...",
+		"cities_sort_concerts": "Nombre de concerts",
+		"cities_sort_date":     "Concert le plus récent",
+		"cities_sort_name":     "Nom (A-Z)",
+		"cities_select":        "Sélectionnez une ville",
+		"cities_row":           "%d concerts · %d artistes",
+		"cities_period":        "Période",
+		"cities_map":           "Voir sur la carte",
+		"cal_title":            "CALENDRIER DES CONCERTS",
+		"cal_months":           "Janvier,Février,Mars,Avril,Mai,Juin,Juillet,Août,Septembre,Octobre,Novembre,Décembre",
+		"cal_days":             "Lun,Mar,Mer,Jeu,Ven,Sam,Dim",
+		"cal_more":             "+%d autres",
+		"cal_count":            "%d concerts ce mois-ci",
+		"member_bands":         "Présent dans %d groupe(s)",
+		"member_others":        "Autres membres :",
+		"member_results":       "Membres :",
+		"graph_title":          "MEMBRES PARTAGÉS",
+		"graph_empty":          "Aucun membre n'apparaît dans plusieurs groupes.",
+		"map_title":            "Carte du monde",
+		"map_done":             "%d villes de concert",
+		"sc_title":             "Raccourcis clavier",
+		"sc_search":            "Rechercher un artiste",
+		"sc_new":               "Créer un groupe",
+		"sc_settings":          "Paramètres",
+		"sc_grid":              "Basculer liste / grille",
+		"sc_move":              "Se déplacer dans la liste",
+		"sc_open":              "Ouvrir la fiche",
+		"sc_fav":               "Ajouter / retirer des favoris",
+		"sc_back":              "Revenir en arrière",
+		"sc_history":           "Précédent / suivant",
+		"sc_help":              "Afficher cette aide",
+		"sc_note":              "Les flèches, Entrée, F et Échap agissent quand aucun champ de saisie n'a le focus : cliquez à côté d'un champ pour le quitter.",
+		"open_window":          "Ouvrir dans une nouvelle fenêtre",
 	},
 	"EN": {
 		"app_title":       "GROUPIE // DATABASE",
@@ -87,13 +221,147 @@ var dictionary = map[string]map[string]string{
 		"no_data":      "No data available",
 		"wiki_btn":     "WIKIPEDIA",
 
-		"btn_export":    "Export Favorites",
-		"btn_import":    "Import Favorites",
+		"btn_export":    "Export my data",
+		"btn_import":    "Import my data",
 		"btn_about":     "About",
 		"success_title": "Success",
-		"export_msg":    "List exported successfully!",
-		"import_msg":    "List imported successfully!",
+		"export_msg":    "Backup exported successfully!",
+		"import_msg":    "Backup imported successfully!",
 		"about_text":    "Groupie Tracker v2.0\nCreated by Paul, Aboubakar, Lina\nStudent Project Ynov",
+
+		// Favoris enrichis
+		"fav_notes":     "Personal notes",
+		"fav_rating":    "Rating",
+		"fav_tags":      "Tags (comma separated)",
+		"fav_note":      "Comment",
+		"fav_save":      "Save",
+		"fav_saved":     "Notes saved!",
+		"fav_removed":   "This artist is no longer a favorite: notes not saved.",
+		"fav_added_on":  "Added on",
+		"rating_none":   "None",
+		"rating_filter": "Minimum rating",
+		"rating_any":    "Any",
+		"tag_filter":    "Tag",
+
+		// Collections
+		"coll_title":      "COLLECTIONS",
+		"coll_new":        "New collection",
+		"coll_rename":     "Rename",
+		"coll_delete":     "Delete",
+		"coll_name":       "Name",
+		"coll_select":     "Select a collection",
+		"coll_empty":      "Empty collection: add artists from their detail page",
+		"coll_none":       "No collection yet: create one from the Collections screen",
+		"coll_exists":     "A collection with this name already exists",
+		"coll_name_empty": "The name cannot be empty",
+		"coll_all":        "All",
+		"coll_filter":     "Collection",
+		"btn_cancel":      "Cancel",
+
+		// Sauvegardes et préréglages
+		"import_preview":       "Import preview",
+		"import_mode":          "Mode",
+		"import_merge":         "Merge",
+		"import_replace":       "Replace",
+		"btn_apply":            "Import",
+		"prev_line":            "%s: %d added, %d updated, %d removed",
+		"prev_favorites":       "Favorites",
+		"prev_local":           "Local bands",
+		"prev_presets":         "Presets",
+		"prev_prefs":           "Preferences will be replaced",
+		"prev_nothing":         "No changes",
+		"err_unknown_ids":      "The backup references unknown artists:",
+		"err_checksum":         "Invalid checksum: the file was modified or truncated.",
+		"err_backup_version":   "This backup comes from a newer version of the application.",
+		"err_backup_format":    "Unrecognized backup file.",
+		"preset_label":         "Preset",
+		"preset_place":         "Load a preset...",
+		"preset_save":          "Save preset",
+		"preset_delete":        "Delete preset",
+		"preset_name":          "Name",
+		"btn_export_list":      "Export list",
+		"export_format":        "Format",
+		"export_list_msg":      "%d artist(s) exported",
+		"btn_calendar":         "Calendar (.ics)",
+		"btn_fav_calendar":     "Favorites' concerts",
+		"calendar_empty":       "No concerts to export.",
+		"calendar_no_fav":      "No favorites: add artists to your favorites to export their concerts.",
+		"calendar_msg":         "%d concert(s) exported",
+		"calendar_name":        "My favorites' concerts",
+		"export_confirm":       "Export",
+		"btn_geo":              "Map (GeoJSON / KML)",
+		"geo_tour":             "Draw tours (GeoJSON / KML)",
+		"geo_loading":          "Loading concerts...",
+		"geo_progress":         "Geocoding places: %d/%d",
+		"geo_msg":              "%d place(s) exported.",
+		"geo_missing":          "Places not found, skipped:",
+		"dash_title":           "STATISTICS",
+		"stats_empty":          "No data",
+		"stats_artists":        "Artists",
+		"stats_favorites":      "Favorites",
+		"stats_catalog":        "Catalogue",
+		"stats_countries":      "Countries",
+		"stats_avg_members":    "Members (avg)",
+		"stats_avg_gap":        "Years to 1st album",
+		"stats_decades":        "Bands by creation decade",
+		"stats_members":        "Number of members",
+		"stats_album_gap":      "Years between creation and 1st album",
+		"stats_fav_vs_catalog": "Favorites / catalogue by decade",
+		"stats_years":          "Concerts per year",
+		"stats_countries_top":  "Top %d countries (concerts)",
+		"stats_cities_top":     "Top %d cities (concerts)",
+		"tl_title":             "CONCERT TIMELINE",
+		"tl_year":              "Years",
+		"tl_month":             "Months",
+		"tl_hint":              "Hover a dot to see the concert",
+		"tl_creation":          "Formed",
+		"tl_album":             "1st album",
+		"compare_btn":          "Compare (%d)",
+		"compare_check":        "Compare",
+		"compare_title":        "COMPARISON",
+		"compare_shared":       "Shared cities",
+		"compare_overlaps":     "Overlapping tours",
+		"compare_none":         "None",
+		"compare_map":          "Combined map",
+		"compare_tour":         "%s: %s – %s (%d concerts)",
+		"similar_title":        "Similar artists",
+		"similar_sub":          "Era, members, cities and tours",
+		"suggest_title":        "Suggestions:",
+		"suggest_because":      "%s (because you favorited %s)",
+		"cities_title":         "CITIES",
+		"cities_search":        "Search a city or country...",
+		"cities_sort_concerts": "Number of concerts",
+		"cities_sort_date":     "Most recent concert",
+		"cities_sort_name":     "Name (A-Z)",
+		"cities_select":        "Select a city",
+		"cities_row":           "%d concerts · %d artists",
+		"cities_period":        "Period",
+		"cities_map":           "Show on map",
+		"cal_title":            "CONCERT CALENDAR",
+		"cal_months":           "January,February,March,April,May,June,July,August,September,October,November,December",
+		"cal_days":             "Mon,Tue,Wed,Thu,Fri,Sat,Sun",
+		"cal_more":             "+%d more",
+		"cal_count":            "%d concerts this month",
+		"member_bands":         "Member of %d band(s)",
+		"member_others":        "Other members:",
+		"member_results":       "Members:",
+		"graph_title":          "SHARED MEMBERS",
+		"graph_empty":          "No member appears in more than one band.",
+		"map_title":            "World map",
+		"map_done":             "%d concert cities",
+		"sc_title":             "Keyboard shortcuts",
+		"sc_search":            "Search artists",
+		"sc_new":               "Create a band",
+		"sc_settings":          "Settings",
+		"sc_grid":              "Toggle list / grid",
+		"sc_move":              "Move through the list",
+		"sc_open":              "Open the details",
+		"sc_fav":               "Add / remove favorite",
+		"sc_back":              "Go back",
+		"sc_history":           "Back / forward",
+		"sc_help":              "Show this help",
+		"sc_note":              "Arrows, Enter, F and Esc work when no text field has focus: click outside a field to leave it.",
+		"open_window":          "Open in new window",
 	},
 	"ES": {
 		"app_title":       "GROUPIE // BASE DE DATOS",
@@ -132,13 +400,147 @@ var dictionary = map[string]map[string]string{
 		"no_data":      "No hay datos disponibles",
 		"wiki_btn":     "WIKIPEDIA",
 
-		"btn_export":    "Exportar Favoritos",
-		"btn_import":    "Importar Favoritos",
+		"btn_export":    "Exportar mis datos",
+		"btn_import":    "Importar mis datos",
 		"btn_about":     "Acerca de",
 		"success_title": "Éxito",
-		"export_msg":    "¡Lista exportada con éxito!",
-		"import_msg":    "¡Lista importada con éxito!",
+		"export_msg":    "¡Copia exportada con éxito!",
+		"import_msg":    "¡Copia importada con éxito!",
 		"about_text":    "Groupie Tracker v2.0\nCreado por Paul, Aboubakar, Lina\nProyecto Estudiantil Ynov",
+
+		// Favoris enrichis
+		"fav_notes":     "Notas personales",
+		"fav_rating":    "Valoración",
+		"fav_tags":      "Etiquetas (separadas por comas)",
+		"fav_note":      "Comentario",
+		"fav_save":      "Guardar",
+		"fav_saved":     "¡Notas guardadas!",
+		"fav_removed":   "Este artista ya no está en tus favoritos: notas no guardadas.",
+		"fav_added_on":  "Añadido el",
+		"rating_none":   "Ninguna",
+		"rating_filter": "Valoración mínima",
+		"rating_any":    "Todas",
+		"tag_filter":    "Etiqueta",
+
+		// Collections
+		"coll_title":      "COLECCIONES",
+		"coll_new":        "Nueva colección",
+		"coll_rename":     "Renombrar",
+		"coll_delete":     "Eliminar",
+		"coll_name":       "Nombre",
+		"coll_select":     "Seleccione una colección",
+		"coll_empty":      "Colección vacía: añada artistas desde su ficha",
+		"coll_none":       "Ninguna colección: cree una desde la pantalla Colecciones",
+		"coll_exists":     "Ya existe una colección con este nombre",
+		"coll_name_empty": "El nombre no puede estar vacío",
+		"coll_all":        "Todas",
+		"coll_filter":     "Colección",
+		"btn_cancel":      "Cancelar",
+
+		// Sauvegardes et préréglages
+		"import_preview":       "Vista previa de la importación",
+		"import_mode":          "Modo",
+		"import_merge":         "Combinar",
+		"import_replace":       "Reemplazar",
+		"btn_apply":            "Importar",
+		"prev_line":            "%s: %d añadido(s), %d modificado(s), %d eliminado(s)",
+		"prev_favorites":       "Favoritos",
+		"prev_local":           "Grupos locales",
+		"prev_presets":         "Preajustes",
+		"prev_prefs":           "Las preferencias serán reemplazadas",
+		"prev_nothing":         "Sin cambios",
+		"err_unknown_ids":      "La copia hace referencia a artistas desconocidos:",
+		"err_checksum":         "Suma de control inválida: el archivo fue modificado o truncado.",
+		"err_backup_version":   "Esta copia proviene de una versión más reciente de la aplicación.",
+		"err_backup_format":    "Archivo de copia no reconocido.",
+		"preset_label":         "Preajuste",
+		"preset_place":         "Cargar un preajuste...",
+		"preset_save":          "Guardar preajuste",
+		"preset_delete":        "Eliminar preajuste",
+		"preset_name":          "Nombre",
+		"btn_export_list":      "Exportar la lista",
+		"export_format":        "Formato",
+		"export_list_msg":      "%d artista(s) exportado(s)",
+		"btn_calendar":         "Agenda (.ics)",
+		"btn_fav_calendar":     "Conciertos de favoritos",
+		"calendar_empty":       "No hay conciertos para exportar.",
+		"calendar_no_fav":      "Sin favoritos: añade artistas a tus favoritos para exportar sus conciertos.",
+		"calendar_msg":         "%d concierto(s) exportado(s)",
+		"calendar_name":        "Conciertos de mis favoritos",
+		"export_confirm":       "Exportar",
+		"btn_geo":              "Mapa (GeoJSON / KML)",
+		"geo_tour":             "Trazar las giras (GeoJSON / KML)",
+		"geo_loading":          "Cargando conciertos...",
+		"geo_progress":         "Geocodificando lugares: %d/%d",
+		"geo_msg":              "%d lugar(es) exportado(s).",
+		"geo_missing":          "Lugares no encontrados, omitidos:",
+		"dash_title":           "ESTADÍSTICAS",
+		"stats_empty":          "Sin datos",
+		"stats_artists":        "Artistas",
+		"stats_favorites":      "Favoritos",
+		"stats_catalog":        "Catálogo",
+		"stats_countries":      "Países",
+		"stats_avg_members":    "Miembros (media)",
+		"stats_avg_gap":        "Años hasta el 1er álbum",
+		"stats_decades":        "Grupos por década de creación",
+		"stats_members":        "Número de miembros",
+		"stats_album_gap":      "Años entre creación y 1er álbum",
+		"stats_fav_vs_catalog": "Favoritos / catálogo por década",
+		"stats_years":          "Conciertos por año",
+		"stats_countries_top":  "Top %d países (conciertos)",
+		"stats_cities_top":     "Top %d ciudades (conciertos)",
+		"tl_title":             "LÍNEA DE TIEMPO",
+		"tl_year":              "Años",
+		"tl_month":             "Meses",
+		"tl_hint":              "Pasa el ratón sobre un punto para ver el concierto",
+		"tl_creation":          "Creación",
+		"tl_album":             "1er álbum",
+		"compare_btn":          "Comparar (%d)",
+		"compare_check":        "Comparar",
+		"compare_title":        "COMPARACIÓN",
+		"compare_shared":       "Ciudades en común",
+		"compare_overlaps":     "Giras simultáneas",
+		"compare_none":         "Ninguna",
+		"compare_map":          "Mapa combinado",
+		"compare_tour":         "%s: %s – %s (%d conciertos)",
+		"similar_title":        "Artistas similares",
+		"similar_sub":          "Época, miembros, ciudades y giras",
+		"suggest_title":        "Sugerencias:",
+		"suggest_because":      "%s (porque te gusta %s)",
+		"cities_title":         "CIUDADES",
+		"cities_search":        "Buscar una ciudad o un país...",
+		"cities_sort_concerts": "Número de conciertos",
+		"cities_sort_date":     "Concierto más reciente",
+		"cities_sort_name":     "Nombre (A-Z)",
+		"cities_select":        "Seleccione una ciudad",
+		"cities_row":           "%d conciertos · %d artistas",
+		"cities_period":        "Período",
+		"cities_map":           "Ver en el mapa",
+		"cal_title":            "CALENDARIO DE CONCIERTOS",
+		"cal_months":           "Enero,Febrero,Marzo,Abril,Mayo,Junio,Julio,Agosto,Septiembre,Octubre,Noviembre,Diciembre",
+		"cal_days":             "Lun,Mar,Mié,Jue,Vie,Sáb,Dom",
+		"cal_more":             "+%d más",
+		"cal_count":            "%d conciertos este mes",
+		"member_bands":         "Miembro de %d grupo(s)",
+		"member_others":        "Otros miembros:",
+		"member_results":       "Miembros:",
+		"graph_title":          "MIEMBROS COMPARTIDOS",
+		"graph_empty":          "Ningún miembro aparece en varios grupos.",
+		"map_title":            "Mapa del mundo",
+		"map_done":             "%d ciudades de conciertos",
+		"sc_title":             "Atajos de teclado",
+		"sc_search":            "Buscar un artista",
+		"sc_new":               "Crear un grupo",
+		"sc_settings":          "Ajustes",
+		"sc_grid":              "Alternar lista / cuadrícula",
+		"sc_move":              "Moverse por la lista",
+		"sc_open":              "Abrir la ficha",
+		"sc_fav":               "Añadir / quitar de favoritos",
+		"sc_back":              "Volver atrás",
+		"sc_history":           "Anterior / siguiente",
+		"sc_help":              "Mostrar esta ayuda",
+		"sc_note":              "Las flechas, Intro, F y Esc funcionan cuando ningún campo de texto tiene el foco: haga clic fuera de un campo para salir.",
+		"open_window":          "Abrir en una ventana nueva",
 	},
 	"DE": {
 		"app_title":       "GROUPIE // DATENBANK",
@@ -177,13 +579,147 @@ var dictionary = map[string]map[string]string{
 		"no_data":      "Keine Daten verfügbar",
 		"wiki_btn":     "WIKIPEDIA",
 
-		"btn_export":    "Favoriten exportieren",
-		"btn_import":    "Favoriten importieren",
+		"btn_export":    "Meine Daten exportieren",
+		"btn_import":    "Meine Daten importieren",
 		"btn_about":     "Über",
 		"success_title": "Erfolg",
-		"export_msg":    "Liste erfolgreich exportiert!",
-		"import_msg":    "Liste erfolgreich importiert!",
+		"export_msg":    "Sicherung erfolgreich exportiert!",
+		"import_msg":    "Sicherung erfolgreich importiert!",
 		"about_text":    "Groupie Tracker v2.0\nErstellt von Paul, Aboubakar, Lina\nStudentenprojekt Ynov",
+
+		// Favoris enrichis
+		"fav_notes":     "Persönliche Notizen",
+		"fav_rating":    "Bewertung",
+		"fav_tags":      "Tags (durch Kommas getrennt)",
+		"fav_note":      "Kommentar",
+		"fav_save":      "Speichern",
+		"fav_saved":     "Notizen gespeichert!",
+		"fav_removed":   "Dieser Künstler ist kein Favorit mehr: Notizen nicht gespeichert.",
+		"fav_added_on":  "Hinzugefügt am",
+		"rating_none":   "Keine",
+		"rating_filter": "Mindestbewertung",
+		"rating_any":    "Alle",
+		"tag_filter":    "Tag",
+
+		// Collections
+		"coll_title":      "SAMMLUNGEN",
+		"coll_new":        "Neue Sammlung",
+		"coll_rename":     "Umbenennen",
+		"coll_delete":     "Löschen",
+		"coll_name":       "Name",
+		"coll_select":     "Wählen Sie eine Sammlung",
+		"coll_empty":      "Leere Sammlung: Künstler über ihre Detailseite hinzufügen",
+		"coll_none":       "Noch keine Sammlung: im Bildschirm Sammlungen erstellen",
+		"coll_exists":     "Eine Sammlung mit diesem Namen existiert bereits",
+		"coll_name_empty": "Der Name darf nicht leer sein",
+		"coll_all":        "Alle",
+		"coll_filter":     "Sammlung",
+		"btn_cancel":      "Abbrechen",
+
+		// Sauvegardes et préréglages
+		"import_preview":       "Importvorschau",
+		"import_mode":          "Modus",
+		"import_merge":         "Zusammenführen",
+		"import_replace":       "Ersetzen",
+		"btn_apply":            "Importieren",
+		"prev_line":            "%s: %d hinzugefügt, %d geändert, %d entfernt",
+		"prev_favorites":       "Favoriten",
+		"prev_local":           "Lokale Bands",
+		"prev_presets":         "Voreinstellungen",
+		"prev_prefs":           "Die Einstellungen werden ersetzt",
+		"prev_nothing":         "Keine Änderungen",
+		"err_unknown_ids":      "Die Sicherung verweist auf unbekannte Künstler:",
+		"err_checksum":         "Ungültige Prüfsumme: Die Datei wurde verändert oder abgeschnitten.",
+		"err_backup_version":   "Diese Sicherung stammt aus einer neueren Version der Anwendung.",
+		"err_backup_format":    "Unbekannte Sicherungsdatei.",
+		"preset_label":         "Voreinstellung",
+		"preset_place":         "Voreinstellung laden...",
+		"preset_save":          "Voreinstellung speichern",
+		"preset_delete":        "Voreinstellung löschen",
+		"preset_name":          "Name",
+		"btn_export_list":      "Liste exportieren",
+		"export_format":        "Format",
+		"export_list_msg":      "%d Künstler exportiert",
+		"btn_calendar":         "Kalender (.ics)",
+		"btn_fav_calendar":     "Konzerte der Favoriten",
+		"calendar_empty":       "Keine Konzerte zum Exportieren.",
+		"calendar_no_fav":      "Keine Favoriten: Fügen Sie Künstler zu Ihren Favoriten hinzu, um deren Konzerte zu exportieren.",
+		"calendar_msg":         "%d Konzert(e) exportiert",
+		"calendar_name":        "Konzerte meiner Favoriten",
+		"export_confirm":       "Exportieren",
+		"btn_geo":              "Karte (GeoJSON / KML)",
+		"geo_tour":             "Tourneen zeichnen (GeoJSON / KML)",
+		"geo_loading":          "Konzerte werden geladen...",
+		"geo_progress":         "Orte werden geokodiert: %d/%d",
+		"geo_msg":              "%d Ort(e) exportiert.",
+		"geo_missing":          "Nicht gefundene Orte, übersprungen:",
+		"dash_title":           "STATISTIKEN",
+		"stats_empty":          "Keine Daten",
+		"stats_artists":        "Künstler",
+		"stats_favorites":      "Favoriten",
+		"stats_catalog":        "Katalog",
+		"stats_countries":      "Länder",
+		"stats_avg_members":    "Mitglieder (Ø)",
+		"stats_avg_gap":        "Jahre bis zum 1. Album",
+		"stats_decades":        "Bands nach Gründungsjahrzehnt",
+		"stats_members":        "Anzahl der Mitglieder",
+		"stats_album_gap":      "Jahre zwischen Gründung und 1. Album",
+		"stats_fav_vs_catalog": "Favoriten / Katalog nach Jahrzehnt",
+		"stats_years":          "Konzerte pro Jahr",
+		"stats_countries_top":  "Top %d Länder (Konzerte)",
+		"stats_cities_top":     "Top %d Städte (Konzerte)",
+		"tl_title":             "KONZERT-ZEITLEISTE",
+		"tl_year":              "Jahre",
+		"tl_month":             "Monate",
+		"tl_hint":              "Bewegen Sie die Maus über einen Punkt, um das Konzert zu sehen",
+		"tl_creation":          "Gründung",
+		"tl_album":             "1. Album",
+		"compare_btn":          "Vergleichen (%d)",
+		"compare_check":        "Vergleichen",
+		"compare_title":        "VERGLEICH",
+		"compare_shared":       "Gemeinsame Städte",
+		"compare_overlaps":     "Gleichzeitige Tourneen",
+		"compare_none":         "Keine",
+		"compare_map":          "Gemeinsame Karte",
+		"compare_tour":         "%s: %s – %s (%d Konzerte)",
+		"similar_title":        "Ähnliche Künstler",
+		"similar_sub":          "Epoche, Mitglieder, Städte und Tourneen",
+		"suggest_title":        "Vorschläge:",
+		"suggest_because":      "%s (weil Sie %s mögen)",
+		"cities_title":         "STÄDTE",
+		"cities_search":        "Stadt oder Land suchen...",
+		"cities_sort_concerts": "Anzahl der Konzerte",
+		"cities_sort_date":     "Neuestes Konzert",
+		"cities_sort_name":     "Name (A-Z)",
+		"cities_select":        "Wählen Sie eine Stadt",
+		"cities_row":           "%d Konzerte · %d Künstler",
+		"cities_period":        "Zeitraum",
+		"cities_map":           "Auf der Karte zeigen",
+		"cal_title":            "KONZERTKALENDER",
+		"cal_months":           "Januar,Februar,März,April,Mai,Juni,Juli,August,September,Oktober,November,Dezember",
+		"cal_days":             "Mo,Di,Mi,Do,Fr,Sa,So",
+		"cal_more":             "+%d weitere",
+		"cal_count":            "%d Konzerte in diesem Monat",
+		"member_bands":         "Mitglied in %d Band(s)",
+		"member_others":        "Andere Mitglieder:",
+		"member_results":       "Mitglieder:",
+		"graph_title":          "GEMEINSAME MITGLIEDER",
+		"graph_empty":          "Kein Mitglied ist in mehreren Bands.",
+		"map_title":            "Weltkarte",
+		"map_done":             "%d Konzertstädte",
+		"sc_title":             "Tastenkürzel",
+		"sc_search":            "Künstler suchen",
+		"sc_new":               "Band erstellen",
+		"sc_settings":          "Einstellungen",
+		"sc_grid":              "Liste / Raster umschalten",
+		"sc_move":              "In der Liste bewegen",
+		"sc_open":              "Details öffnen",
+		"sc_fav":               "Favorit hinzufügen / entfernen",
+		"sc_back":              "Zurück",
+		"sc_history":           "Zurück / vor",
+		"sc_help":              "Diese Hilfe anzeigen",
+		"sc_note":              "Pfeile, Eingabe, F und Esc wirken, wenn kein Textfeld den Fokus hat: Klicken Sie neben ein Feld, um es zu verlassen.",
+		"open_window":          "In neuem Fenster öffnen",
 	},
 }
 
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"image/color"
+
+	"groupie-tracker/images"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// artistImage affiche une image d'artiste chargée par le service d'images :
+// un fond gris pendant le chargement, une icône d'image cassée en cas d'échec.
+type artistImage struct {
+	size float32
+	url  string
+
+	img    *canvas.Image
+	bg     *canvas.Rectangle
+	broken *widget.Icon
+	box    *fyne.Container
+}
+
+func newArtistImage(size float32) *artistImage {
+	a := &artistImage{size: size}
+	a.bg = canvas.NewRectangle(color.NRGBA{R: 40, G: 40, B: 50, A: 255})
+	a.bg.SetMinSize(fyne.NewSize(size, size))
+	a.img = canvas.NewImageFromImage(nil)
+	a.img.FillMode = canvas.ImageFillContain
+	a.img.SetMinSize(fyne.NewSize(size, size))
+	a.broken = widget.NewIcon(theme.BrokenImageIcon())
+	a.broken.Hide()
+	a.box = container.NewStack(a.bg, container.NewCenter(a.broken), a.img)
+	return a
+}
+
+// load charge url ; un chargement remplacé entre-temps (cellule réutilisée) est ignoré
+func (a *artistImage) load(url string) {
+	if url == a.url {
+		return
+	}
+	a.url = url
+	a.img.Image = nil
+	a.img.Refresh()
+	a.broken.Hide()
+
+	go func() {
+		thumb, err := images.Thumbnail(url, int(a.size))
+		fyne.Do(func() {
+			if a.url != url {
+				return
+			}
+			if err != nil {
+				a.broken.Show()
+				return
+			}
+			a.img.Image = thumb
+			a.img.Refresh()
+		})
+	}()
+}
@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"groupie-tracker/images"
+	"groupie-tracker/models"
+	"groupie-tracker/stats"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Graphe des membres partagés : une case par ensemble d'artistes reliés,
+// les artistes d'un ensemble sont placés en cercle
+const (
+	graphCell    = 300
+	graphRadius  = 95
+	graphNode    = 14
+	graphColumns = 3
+)
+
+// Nombre maximal de membres proposés sous la recherche
+const memberResultsMax = 20
+
+// memberLinks affiche des membres cliquables, sauf except
+func memberLinks(members []string, except string, onOpenMember func(string)) fyne.CanvasObject {
+	box := container.NewVBox()
+	for _, m := range members {
+		if stats.MemberKey(m) == stats.MemberKey(except) {
+			continue
+		}
+		link := widget.NewHyperlink(strings.TrimSpace(m), nil)
+		link.OnTapped = func() { onOpenMember(m) }
+		box.Add(link)
+	}
+	return box
+}
+
+// MemberScreen affiche la page d'un membre : tous les groupes où il apparaît,
+// avec leurs autres membres
+func MemberScreen(name string, artists []models.Artist, onBack func(), onOpenArtist func(artist models.Artist, onClosed func()), onOpenMember func(string)) fyne.CanvasObject {
+	title := canvas.NewText(strings.ToUpper(strings.TrimSpace(name)), ColAccent)
+	title.TextSize = 20
+	title.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
+
+	bands := stats.BandsOf(name, artists)
+	body := container.NewVBox(widget.NewLabel(fmt.Sprintf(TR("member_bands"), len(bands))))
+	for _, a := range bands {
+		img := newArtistImage(images.SizeList)
+		img.load(a.Image)
+		lbl := canvas.NewText(strings.ToUpper(a.Name), ColAccent)
+		lbl.TextStyle = fyne.TextStyle{Bold: true, Monospace: true}
+		info := widget.NewLabel(fmt.Sprintf("%s %d | %d %s", TR("since"), a.CreationDate, len(a.Members), TR("members")))
+		btnSee := widget.NewButton(TR("see_btn"), func() { onOpenArtist(a, func() {}) })
+
+		others := container.NewBorder(nil, nil, widget.NewLabel(TR("member_others")), nil, memberLinks(a.Members, name, onOpenMember))
+		row := container.NewBorder(nil, nil, img.box, btnSee, container.NewVBox(lbl, info, others))
+		body.Add(container.NewMax(canvas.NewRectangle(ColCard), container.NewPadded(row)))
+	}
+
+	header := container.NewVBox(
+		container.NewBorder(nil, nil,
+			widget.NewButtonWithIcon(TR("back_btn"), theme.NavigateBackIcon(), onBack),
+			nil,
+			container.NewCenter(title),
+		),
+		widget.NewSeparator(),
+	)
+
+	return container.NewMax(canvas.NewRectangle(ColBackground), container.NewBorder(header, nil, nil, nil, container.NewVScroll(container.NewPadded(body))))
+}
+
+// linkGroups regroupe les artistes reliés, directement ou de proche en proche,
+// les groupes les plus grands d'abord
+func linkGroups(links []stats.Link) [][]int {
+	parent := make(map[int]int)
+	var find func(id int) int
+	find = func(id int) int {
+		if p, ok := parent[id]; ok && p != id {
+			parent[id] = find(p)
+			return parent[id]
+		}
+		parent[id] = id
+		return id
+	}
+	for _, l := range links {
+		parent[find(l.A)] = find(l.B)
+	}
+
+	byRoot := make(map[int][]int)
+	for id := range parent {
+		root := find(id)
+		byRoot[root] = append(byRoot[root], id)
+	}
+	groups := make([][]int, 0, len(byRoot))
+	for _, ids := range byRoot {
+		slices.Sort(ids)
+		groups = append(groups, ids)
+	}
+	slices.SortFunc(groups, func(a, b []int) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return a[0] - b[0]
+	})
+	return groups
+}
+
+// MemberGraphScreen dessine les artistes reliés par des membres communs. Un
+// nom d'artiste ouvre sa fiche, un nom de membre sa page.
+func MemberGraphScreen(artists []models.Artist, onBack func(), onOpenArtist func(artist models.Artist, onClosed func()), onOpenMember func(string)) fyne.CanvasObject {
+	byID := make(map[int]models.Artist, len(artists))
+	for _, a := range artists {
+		byID[a.ID] = a
+	}
+
+	title := canvas.NewText(TR("graph_title"), ColAccent)
+	title.TextSize = 20
+	title.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
+
+	links := stats.SharedMembers(artists)
+	groups := linkGroups(links)
+
+	// Position de chaque artiste : en cercle au centre de la case de son groupe
+	pos := make(map[int]fyne.Position)
+	for g, ids := range groups {
+		cx := float32(g%graphColumns)*graphCell + graphCell/2
+		cy := float32(g/graphColumns)*graphCell + graphCell/2
+		for i, id := range ids {
+			angle := 2*math.Pi*float64(i)/float64(len(ids)) - math.Pi/2
+			if len(ids) == 2 {
+				angle = math.Pi * float64(i) // une paire est posée à l'horizontale
+			}
+			pos[id] = fyne.NewPos(cx+float32(graphRadius*math.Cos(angle)), cy+float32(graphRadius*math.Sin(angle)))
+		}
+	}
+
+	graph := container.NewWithoutLayout()
+	for _, l := range links {
+		line := canvas.NewLine(ColAccent)
+		line.StrokeWidth = float32(min(len(l.Members), 4))
+		line.Position1 = pos[l.A]
+		line.Position2 = pos[l.B]
+		graph.Add(line)
+	}
+	for id, p := range pos {
+		dot := canvas.NewCircle(ColHighlight)
+		dot.Resize(fyne.NewSize(graphNode, graphNode))
+		dot.Move(fyne.NewPos(p.X-graphNode/2, p.Y-graphNode/2))
+		graph.Add(dot)
+
+		artist := byID[id]
+		link := widget.NewHyperlink(artist.Name, nil)
+		link.OnTapped = func() { onOpenArtist(artist, func() {}) }
+		size := link.MinSize()
+		link.Resize(size)
+		link.Move(fyne.NewPos(p.X-size.Width/2, p.Y+graphNode/2))
+		graph.Add(link)
+	}
+
+	body := container.NewVBox()
+	if len(links) == 0 {
+		body.Add(widget.NewLabel(TR("graph_empty")))
+	} else {
+		rows := (len(groups) + graphColumns - 1) / graphColumns
+		size := fyne.NewSize(graphColumns*graphCell, float32(rows)*graphCell)
+		body.Add(container.NewCenter(container.NewGridWrap(size, container.NewStack(canvas.NewRectangle(ColCard), graph))))
+
+		// Détail des liens, avec les membres en commun
+		for _, l := range links {
+			lbl := canvas.NewText(byID[l.A].Name+" ⇄ "+byID[l.B].Name, ColAccent)
+			lbl.TextStyle = fyne.TextStyle{Bold: true, Monospace: true}
+			body.Add(container.NewBorder(nil, nil, lbl, nil, memberLinks(l.Members, "", onOpenMember)))
+		}
+	}
+
+	header := container.NewVBox(
+		container.NewBorder(nil, nil,
+			widget.NewButtonWithIcon(TR("back_btn"), theme.NavigateBackIcon(), onBack),
+			nil,
+			container.NewCenter(title),
+		),
+		widget.NewSeparator(),
+	)
+
+	return container.NewMax(canvas.NewRectangle(ColBackground), container.NewBorder(header, nil, nil, nil, container.NewScroll(container.NewPadded(body))))
+}
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"sync"
+	"time"
+
+	"groupie-tracker/store"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+)
+
+// Copie en mémoire des préférences, chargée une fois au démarrage.
+// prefsSave est l'écriture différée en attente (voir updatePreferences).
+var (
+	prefs     = store.DefaultPreferences()
+	prefsSave *time.Timer
+	prefsLock sync.Mutex
+
+	// Sérialise les écritures : la dernière écrite est toujours la plus récente
+	prefsWriteLock sync.Mutex
+)
+
+// Délai avant l'écriture des préférences : une série de changements
+// rapprochés (déplacement de la séparation, tris successifs) n'en donne qu'une
+const prefsSaveDelay = 500 * time.Millisecond
+
+// LoadPreferences restaure la langue et le thème enregistrés.
+// À appeler avant de construire les vues.
+func LoadPreferences(app fyne.App) {
+	p, _ := store.LoadPreferences()
+
+	prefsLock.Lock()
+	prefs = p
+	prefsLock.Unlock()
+
+	if _, ok := dictionary[p.Language]; ok {
+		CurrentLang = p.Language
+	}
+	applyTheme(app, p.Theme)
+}
+
+// RestoreWindow redonne à la fenêtre sa dernière taille et l'enregistre à la
+// fermeture, avec les changements encore en attente d'écriture
+func RestoreWindow(win fyne.Window) {
+	p := currentPreferences()
+	win.Resize(fyne.NewSize(p.WindowWidth, p.WindowHeight))
+	win.SetOnClosed(func() {
+		size := win.Canvas().Size()
+		if size.Width > 0 && size.Height > 0 {
+			updatePreferences(func(p *store.Preferences) {
+				p.WindowWidth, p.WindowHeight = size.Width, size.Height
+			})
+		}
+		// La fenêtre est fermée : l'erreur ne peut plus être affichée
+		if err := flushPreferences(); err != nil {
+			fyne.LogError("sauvegarde des préférences", err)
+		}
+	})
+}
+
+func currentPreferences() store.Preferences {
+	prefsLock.Lock()
+	defer prefsLock.Unlock()
+	return prefs
+}
+
+// updatePreferences modifie les préférences en mémoire. L'écriture sur disque
+// a lieu en arrière-plan, prefsSaveDelay après le dernier changement, comme
+// le filtrage de la liste ; une erreur est affichée dans une boîte de dialogue.
+func updatePreferences(change func(p *store.Preferences)) {
+	prefsLock.Lock()
+	defer prefsLock.Unlock()
+
+	change(&prefs)
+	if prefsSave != nil {
+		prefsSave.Stop()
+	}
+	prefsSave = time.AfterFunc(prefsSaveDelay, func() {
+		if err := savePreferences(); err != nil {
+			fyne.Do(func() { showPreferencesError(err) })
+		}
+	})
+}
+
+// flushPreferences écrit tout de suite les changements en attente
+func flushPreferences() error {
+	prefsLock.Lock()
+	pending := prefsSave != nil && prefsSave.Stop()
+	prefsSave = nil
+	prefsLock.Unlock()
+
+	if !pending {
+		return nil
+	}
+	return savePreferences()
+}
+
+func savePreferences() error {
+	prefsWriteLock.Lock()
+	defer prefsWriteLock.Unlock()
+	return store.SavePreferences(currentPreferences())
+}
+
+func showPreferencesError(err error) {
+	app := fyne.CurrentApp()
+	if app == nil {
+		return
+	}
+	if windows := app.Driver().AllWindows(); len(windows) > 0 {
+		dialog.ShowError(err, windows[0])
+	}
+}
+
+func applyTheme(app fyne.App, name string) {
+	switch name {
+	case "dark":
+		app.Settings().SetTheme(theme.DarkTheme())
+	case "light":
+		app.Settings().SetTheme(theme.LightTheme())
+	}
+}
@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// Routes des vues. Elles s'écrivent comme des chemins ("artist/12") et
+// peuvent être passées en ligne de commande : groupie-tracker artist/12
+const (
+	RouteArtists     = "artists"
+	RouteArtist      = "artist"  // artist/<id>
+	RouteMember      = "member"  // member/<nom>
+	RouteCompare     = "compare" // compare/<id>,<id>,...
+	RouteStats       = "stats"
+	RouteMap         = "map"
+	RouteCities      = "cities"
+	RouteCalendar    = "calendar"
+	RouteMembers     = "members"
+	RouteCollections = "collections"
+	RouteNewBand     = "new" // formulaire de création d'un groupe
+)
+
+// Nombre de vues gardées dans l'historique, liste des artistes comprise
+const historyMax = 50
+
+// routeEntry est une vue de l'historique. onClosed est appelé quand Back la
+// quitte (la vue reste construite pour Forward), onLeave quand elle sort de
+// l'historique (ressources à libérer) ; onKey reçoit ses raccourcis clavier.
+type routeEntry struct {
+	route    string
+	view     fyne.CanvasObject
+	onClosed func()
+	onLeave  func()
+	onKey    func(*fyne.KeyEvent)
+}
+
+// router affiche une vue à la fois et garde l'historique pour les boutons et
+// raccourcis précédent/suivant. Les vues de l'historique restent construites :
+// en y revenant, la liste des artistes retrouve ses filtres et son défilement.
+type router struct {
+	stack   *fyne.Container
+	history []routeEntry
+	pos     int
+	onKey   func(*fyne.KeyEvent) // touches communes à toutes les vues
+}
+
+func newRouter() *router {
+	return &router{stack: container.NewStack()}
+}
+
+// setRoot installe la liste des artistes, première vue de l'historique
+func (r *router) setRoot(view fyne.CanvasObject) {
+	r.history = []routeEntry{{route: RouteArtists, view: view}}
+	r.pos = 0
+	r.show()
+}
+
+// Open affiche view par-dessus la vue courante ; les vues « suivantes »
+// sont oubliées, comme dans un navigateur
+func (r *router) Open(route string, view fyne.CanvasObject, onClosed, onLeave func()) {
+	discard(r.history[r.pos+1:])
+	r.history = append(r.history[:r.pos+1], routeEntry{route: route, view: view, onClosed: onClosed, onLeave: onLeave})
+	if len(r.history) > historyMax {
+		trimmed := len(r.history) - historyMax
+		discard(r.history[1 : 1+trimmed])
+		r.history = append(r.history[:1], r.history[1+trimmed:]...)
+	}
+	r.pos = len(r.history) - 1
+	r.show()
+}
+
+// discard prévient les vues qui sortent de l'historique
+func discard(entries []routeEntry) {
+	for _, e := range entries {
+		if e.onLeave != nil {
+			e.onLeave()
+		}
+	}
+}
+
+// Back revient à la vue précédente
+func (r *router) Back() {
+	if r.pos == 0 {
+		return
+	}
+	left := r.history[r.pos]
+	r.pos--
+	r.show()
+	if left.onClosed != nil {
+		left.onClosed()
+	}
+}
+
+// Forward rouvre la vue quittée par Back
+func (r *router) Forward() {
+	if r.pos >= len(r.history)-1 {
+		return
+	}
+	r.pos++
+	r.show()
+}
+
+// Home revient à la liste des artistes
+func (r *router) Home() {
+	for r.pos > 0 {
+		r.Back()
+	}
+}
+
+// SetKeys associe à la vue affichée les touches frappées hors d'un champ de saisie
+func (r *router) SetKeys(onKey func(*fyne.KeyEvent)) {
+	r.history[r.pos].onKey = onKey
+}
+
+// TypedKey traite une touche : Échap revient en arrière, les autres touches
+// vont à la vue affichée puis aux touches communes
+func (r *router) TypedKey(e *fyne.KeyEvent) {
+	if e.Name == fyne.KeyEscape {
+		r.Back()
+		return
+	}
+	if onKey := r.history[r.pos].onKey; onKey != nil {
+		onKey(e)
+	}
+	if r.onKey != nil {
+		r.onKey(e)
+	}
+}
+
+// Close prévient la vue affichée que la fenêtre se ferme : son onClosed est
+// appelé comme pour Back (la fiche garde sa séparation, par exemple)
+func (r *router) Close() {
+	if left := r.history[r.pos]; r.pos > 0 && left.onClosed != nil {
+		left.onClosed()
+	}
+}
+
+// Reset revient à la liste des artistes et oublie l'historique
+func (r *router) Reset() {
+	r.Home()
+	discard(r.history[1:])
+	r.history = r.history[:1]
+}
+
+// show affiche la vue courante ; la liste reste dans la pile, masquée, pour
+// conserver son état
+func (r *router) show() {
+	root := r.history[0].view
+	if r.pos == 0 {
+		root.Show()
+		r.stack.Objects = []fyne.CanvasObject{root}
+	} else {
+		root.Hide()
+		r.stack.Objects = []fyne.CanvasObject{root, r.history[r.pos].view}
+	}
+	r.stack.Refresh()
+}
+
+// addShortcuts associe Alt+Gauche et Alt+Droite à précédent et suivant, et
+// transmet à la vue les touches frappées quand aucun champ n'a le focus (une
+// boîte de dialogue ouverte garde ses touches)
+func (r *router) addShortcuts(c fyne.Canvas) {
+	c.AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyLeft, Modifier: fyne.KeyModifierAlt}, func(fyne.Shortcut) { r.Back() })
+	c.AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyRight, Modifier: fyne.KeyModifierAlt}, func(fyne.Shortcut) { r.Forward() })
+	c.SetOnTypedKey(func(e *fyne.KeyEvent) {
+		if c.Overlays().Top() == nil {
+			r.TypedKey(e)
+		}
+	})
+}
+
+func artistRoute(id int) string {
+	return RouteArtist + "/" + strconv.Itoa(id)
+}
+
+func memberRoute(name string) string {
+	return RouteMember + "/" + url.PathEscape(strings.TrimSpace(name))
+}
+
+func compareRoute(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return RouteCompare + "/" + strings.Join(parts, ",")
+}
+
+// ParseRoute découpe une route en nom et argument : "artist/12" -> "artist",
+// "12". Le préfixe "groupie-tracker://" est accepté.
+func ParseRoute(route string) (name, arg string, err error) {
+	route = strings.Trim(strings.TrimPrefix(strings.TrimSpace(route), "groupie-tracker://"), "/")
+	name, arg, _ = strings.Cut(route, "/")
+	if arg, err = url.PathUnescape(arg); err != nil {
+		return "", "", fmt.Errorf("route invalide : %s", route)
+	}
+	switch name {
+	case RouteArtists, RouteStats, RouteMap, RouteCities, RouteCalendar, RouteMembers, RouteCollections, RouteNewBand:
+		if arg != "" {
+			return "", "", fmt.Errorf("route invalide : %s", route)
+		}
+	case RouteArtist:
+		if _, err := strconv.Atoi(arg); err != nil {
+			return "", "", fmt.Errorf("route invalide : %s (attendu %s/<id>)", route, RouteArtist)
+		}
+	case RouteMember:
+		if arg == "" {
+			return "", "", fmt.Errorf("route invalide : %s (attendu %s/<nom>)", route, RouteMember)
+		}
+	case RouteCompare:
+		for _, id := range strings.Split(arg, ",") {
+			if _, err := strconv.Atoi(id); err != nil {
+				return "", "", fmt.Errorf("route invalide : %s (attendu %s/<id>,<id>)", route, RouteCompare)
+			}
+		}
+	default:
+		return "", "", fmt.Errorf("route inconnue : %s", route)
+	}
+	return name, arg, nil
+}
@@ -1,8 +1,14 @@
 package ui
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt" // Ajouté pour gérer le texte du compteur
+	"io"
+	"maps"
+	"strings"
+
+	"groupie-tracker/models"
+	"groupie-tracker/store"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -12,9 +18,10 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
-func ShowSettingsModal(app fyne.App, win fyne.Window, onRefresh func()) {
+// ShowSettingsModal affiche les paramètres ; artists sert à valider les sauvegardes importées.
+func ShowSettingsModal(app fyne.App, win fyne.Window, artists []models.Artist, onRefresh func()) {
 	// --- BLOC RAJOUTÉ : COMPTEUR DE FAVORIS ---
-	favsCount := LoadFavorites()
+	favsCount := store.LoadFavorites()
 	count := 0
 	for _, isFav := range favsCount {
 		if isFav {
@@ -37,6 +44,7 @@ func ShowSettingsModal(app fyne.App, win fyne.Window, onRefresh func()) {
 		default:
 			CurrentLang = "FR"
 		}
+		updatePreferences(func(p *store.Preferences) { p.Language = CurrentLang })
 
 		dialog.ShowInformation("Configuration", "Langue modifiée : "+s, win)
 	})
@@ -55,62 +63,52 @@ func ShowSettingsModal(app fyne.App, win fyne.Window, onRefresh func()) {
 
 	// 2. SELECTEUR DE THEME
 	themeSelect := widget.NewSelect([]string{TR("theme_dark"), TR("theme_light")}, func(s string) {
+		name := "light"
 		if s == TR("theme_dark") {
-			app.Settings().SetTheme(theme.DarkTheme())
-		} else {
-			app.Settings().SetTheme(theme.LightTheme())
+			name = "dark"
 		}
+		applyTheme(app, name)
+		updatePreferences(func(p *store.Preferences) { p.Theme = name })
 	})
 	themeSelect.PlaceHolder = TR("theme_label")
+	switch currentPreferences().Theme {
+	case "dark":
+		themeSelect.Selected = TR("theme_dark")
+	case "light":
+		themeSelect.Selected = TR("theme_light")
+	}
 
 	// 3. ACTIONS DE DONNÉES (IMPORT / EXPORT)
 
-	// EXPORT
+	// EXPORT : sauvegarde complète des données utilisateur (voir store.BackupData)
 	btnExport := widget.NewButtonWithIcon(TR("btn_export"), theme.DownloadIcon(), func() {
-		dialog.ShowFileSave(func(writer fyne.URIWriteCloser, err error) {
+		d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
 			if err != nil || writer == nil {
 				return
 			}
 			defer writer.Close()
-
-			favs := LoadFavorites()
-			var ids []int
-			for id, isFav := range favs {
-				if isFav {
-					ids = append(ids, id)
-				}
-			}
-
-			if json.NewEncoder(writer).Encode(ids) == nil {
-				dialog.ShowInformation(TR("success_title"), TR("export_msg"), win)
-			}
-		}, win)
-	})
-	
-	// Correction de la logique OnTapped pour l'export original
-	btnExport.OnTapped = func() {
-		d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
-			if err != nil || writer == nil {
+			// Les préférences en attente d'écriture font partie de la sauvegarde
+			if err := flushPreferences(); err != nil {
+				dialog.ShowError(err, win)
 				return
 			}
-			defer writer.Close()
-			favs := LoadFavorites()
-			var ids []int
-			for id, isFav := range favs {
-				if isFav {
-					ids = append(ids, id)
-				}
+			data, err := store.CurrentBackupData()
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
 			}
-			if json.NewEncoder(writer).Encode(ids) == nil {
-				dialog.ShowInformation(TR("success_title"), TR("export_msg"), win)
+			if err := store.WriteBackup(writer, data); err != nil {
+				dialog.ShowError(err, win)
+				return
 			}
+			dialog.ShowInformation(TR("success_title"), TR("export_msg"), win)
 		}, win)
-		d.SetFileName("favorites_backup.json")
+		d.SetFileName("groupie_backup.json")
 		d.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
 		d.Show()
-	}
+	})
 
-	// IMPORT
+	// IMPORT : vérification puis aperçu des changements avant application
 	btnImport := widget.NewButtonWithIcon(TR("btn_import"), theme.UploadIcon(), func() {
 		d := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
 			if err != nil || reader == nil {
@@ -118,16 +116,44 @@ func ShowSettingsModal(app fyne.App, win fyne.Window, onRefresh func()) {
 			}
 			defer reader.Close()
 
-			var ids []int
-			if json.NewDecoder(reader).Decode(&ids) == nil {
-				newFavs := make(map[int]bool)
-				for _, id := range ids {
-					newFavs[id] = true
-				}
-				SaveFavorites(newFavs)
-				dialog.ShowInformation(TR("success_title"), TR("import_msg"), win)
-				onRefresh() 
+			raw, err := io.ReadAll(reader)
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			incoming, err := store.ReadBackup(raw)
+			if err != nil {
+				dialog.ShowError(backupError(err), win)
+				return
+			}
+			known := make(map[int]bool, len(artists))
+			for _, a := range artists {
+				known[a.ID] = true
+			}
+			if err := incoming.Validate(known); err != nil {
+				dialog.ShowError(backupError(err), win)
+				return
+			}
+			// Une écriture différée ne doit pas écraser les préférences importées
+			if err := flushPreferences(); err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			current, err := store.CurrentBackupData()
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			// Les artistes de l'API : known sans les groupes locaux actuels
+			catalog := maps.Clone(known)
+			for _, b := range current.LocalBands {
+				delete(catalog, b.Artist.ID)
 			}
+			showImportPreview(win, current, incoming, catalog, func() {
+				LoadPreferences(app)
+				dialog.ShowInformation(TR("success_title"), TR("import_msg"), win)
+				onRefresh()
+			})
 		}, win)
 		d.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
 		d.Show()
@@ -138,7 +164,10 @@ func ShowSettingsModal(app fyne.App, win fyne.Window, onRefresh func()) {
 		dialog.ShowConfirm("Confirmation", TR("bonus_clean")+" ?", func(ok bool) {
 			if ok {
 				emptyFav := make(map[int]bool)
-				SaveFavorites(emptyFav)
+				if err := store.SaveFavorites(emptyFav); err != nil {
+					dialog.ShowError(err, win)
+					return
+				}
 				dialog.ShowInformation(TR("success_title"), TR("bonus_clean_msg"), win)
 				onRefresh()
 			}
@@ -183,4 +212,107 @@ func ShowSettingsModal(app fyne.App, win fyne.Window, onRefresh func()) {
 	})
 	d.Resize(fyne.NewSize(400, 500))
 	d.Show()
-}
\ No newline at end of file
+}
+// showImportPreview affiche ce que l'import va changer selon le mode choisi (fusion / remplacement)
+func showImportPreview(win fyne.Window, current, incoming store.BackupData, catalog map[int]bool, onDone func()) {
+	summary := widget.NewLabel("")
+	summary.Wrapping = fyne.TextWrapWord
+
+	var plan store.ImportPlan
+	modeRadio := widget.NewRadioGroup([]string{TR("import_merge"), TR("import_replace")}, func(s string) {
+		mode := store.ImportMerge
+		if s == TR("import_replace") {
+			mode = store.ImportReplace
+		}
+		plan = store.PlanImport(current, incoming, mode, catalog)
+		summary.SetText(importSummary(plan))
+	})
+	modeRadio.Horizontal = true
+	modeRadio.Required = true
+	modeRadio.SetSelected(TR("import_merge"))
+
+	content := container.NewBorder(
+		widget.NewForm(widget.NewFormItem(TR("import_mode"), modeRadio)),
+		nil, nil, nil,
+		container.NewVScroll(summary),
+	)
+
+	d := dialog.NewCustomConfirm(TR("import_preview"), TR("btn_apply"), TR("btn_cancel"), content, func(ok bool) {
+		if !ok || plan.Empty() {
+			return
+		}
+		if err := store.ApplyImport(plan); err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		onDone()
+	}, win)
+	d.Resize(fyne.NewSize(460, 420))
+	d.Show()
+}
+
+// importSummary décrit un plan d'import, une ligne par type de données
+func importSummary(plan store.ImportPlan) string {
+	if plan.Empty() {
+		return TR("prev_nothing")
+	}
+	var lines []string
+	add := func(title string, added, updated, removed []string) {
+		if len(added)+len(updated)+len(removed) == 0 {
+			return
+		}
+		lines = append(lines, fmt.Sprintf(TR("prev_line"), title, len(added), len(updated), len(removed)))
+		if len(added) > 0 {
+			lines = append(lines, "  + "+strings.Join(added, ", "))
+		}
+		if len(updated) > 0 {
+			lines = append(lines, "  ~ "+strings.Join(updated, ", "))
+		}
+		if len(removed) > 0 {
+			lines = append(lines, "  - "+strings.Join(removed, ", "))
+		}
+	}
+	add(TR("prev_favorites"), artistIDLabels(plan.FavoritesAdded), artistIDLabels(plan.FavoritesUpdated), artistIDLabels(plan.FavoritesRemoved))
+	add(TR("coll_title"), plan.CollectionsAdded, plan.CollectionsUpdated, plan.CollectionsRemoved)
+	add(TR("prev_local"), plan.LocalBandsAdded, plan.LocalBandsUpdated, plan.LocalBandsRemoved)
+	add(TR("prev_presets"), plan.PresetsAdded, plan.PresetsUpdated, plan.PresetsRemoved)
+	if plan.PreferencesChanged {
+		lines = append(lines, TR("prev_prefs"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// backupError traduit les erreurs de lecture / validation d'une sauvegarde
+func backupError(err error) error {
+	var unknown *store.UnknownIDsError
+	switch {
+	case errors.As(err, &unknown):
+		var parts []string
+		if len(unknown.Favorites) > 0 {
+			parts = append(parts, TR("prev_favorites")+" : "+joinArtistIDs(unknown.Favorites))
+		}
+		for name, list := range unknown.Collections {
+			parts = append(parts, TR("coll_filter")+" \""+name+"\" : "+joinArtistIDs(list))
+		}
+		return errors.New(TR("err_unknown_ids") + "\n" + strings.Join(parts, "\n"))
+	case errors.Is(err, store.ErrBackupChecksum):
+		return errors.New(TR("err_checksum"))
+	case errors.Is(err, store.ErrBackupVersion):
+		return errors.New(TR("err_backup_version"))
+	case errors.Is(err, store.ErrBackupFormat):
+		return errors.New(TR("err_backup_format"))
+	}
+	return err
+}
+
+func artistIDLabels(ids []int) []string {
+	labels := make([]string, len(ids))
+	for i, id := range ids {
+		labels[i] = fmt.Sprintf("#%d", id)
+	}
+	return labels
+}
+
+func joinArtistIDs(ids []int) string {
+	return strings.Join(artistIDLabels(ids), ", ")
+}
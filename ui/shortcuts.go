@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Raccourcis listés dans l'aide : touches et clé de traduction de l'action
+var shortcutsHelp = []struct{ keys, label string }{
+	{"Ctrl+F", "sc_search"},
+	{"Ctrl+N", "sc_new"},
+	{"Ctrl+,", "sc_settings"},
+	{"Ctrl+G", "sc_grid"},
+	{"↑ ↓ ← →", "sc_move"},
+	{"Entrée", "sc_open"},
+	{"F", "sc_fav"},
+	{"Échap", "sc_back"},
+	{"Alt+← / Alt+→", "sc_history"},
+	{"F1 / Ctrl+/", "sc_help"},
+}
+
+// addCtrlShortcut associe Ctrl (Cmd sur macOS) + key à action sur toute la
+// fenêtre, sauf quand une boîte de dialogue est ouverte
+func addCtrlShortcut(c fyne.Canvas, key fyne.KeyName, action func()) {
+	c.AddShortcut(&desktop.CustomShortcut{KeyName: key, Modifier: fyne.KeyModifierShortcutDefault}, func(fyne.Shortcut) {
+		if c.Overlays().Top() == nil {
+			action()
+		}
+	})
+}
+
+// showShortcuts affiche l'aide-mémoire des raccourcis clavier
+func showShortcuts(win fyne.Window) {
+	grid := container.NewGridWithColumns(2)
+	for _, s := range shortcutsHelp {
+		keys := widget.NewLabel(s.keys)
+		keys.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
+		grid.Add(keys)
+		grid.Add(widget.NewLabel(TR(s.label)))
+	}
+	note := widget.NewLabel(TR("sc_note"))
+	note.Wrapping = fyne.TextWrapWord
+	d := dialog.NewCustom(TR("sc_title"), TR("btn_close"), container.NewVBox(grid, widget.NewSeparator(), note), win)
+	d.Resize(fyne.NewSize(520, 0))
+	d.Show()
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+
+	"groupie-tracker/models"
+	"groupie-tracker/stats"
+	"groupie-tracker/store"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Nombre d'artistes similaires sur la fiche et de suggestions sur la liste
+const (
+	similarCount = 5
+	suggestCount = 8
+)
+
+// similarArtistsCard liste les artistes les plus proches. Le score ne tient
+// d'abord compte que de l'époque et des membres, puis est recalculé avec les
+// concerts une fois ceux-ci chargés.
+func similarArtistsCard(artist models.Artist, catalog []models.Artist, onOpen func(models.Artist)) fyne.CanvasObject {
+	list := container.NewVBox()
+	status := widget.NewLabel(TR("geo_loading"))
+
+	render := func(concerts map[int][]models.Concert) {
+		list.Objects = nil
+		for _, m := range stats.Similar(artist, catalog, concerts, similarCount) {
+			a := m.Artist
+			btn := widget.NewButtonWithIcon(fmt.Sprintf("%s — %.0f %%", a.Name, m.Score*100), theme.NavigateNextIcon(), func() { onOpen(a) })
+			btn.Alignment = widget.ButtonAlignLeading
+			list.Add(btn)
+		}
+		if len(list.Objects) == 0 {
+			list.Add(widget.NewLabel(TR("compare_none")))
+		}
+		list.Refresh()
+	}
+	render(nil)
+
+	go func() {
+		concerts, err := loadConcerts(catalog)
+		fyne.Do(func() {
+			if err != nil {
+				status.SetText(err.Error())
+				return
+			}
+			status.Hide()
+			render(concerts)
+		})
+	}()
+
+	return widget.NewCard(TR("similar_title"), TR("similar_sub"), container.NewVBox(list, status))
+}
+
+// suggestionsBar affiche les recommandations tirées des favoris ; update
+// recalcule la barre, qui reste masquée tant qu'il n'y a aucun favori
+type suggestionsBar struct {
+	box    *fyne.Container
+	title  *widget.Label
+	items  *fyne.Container
+	onOpen func(models.Artist)
+	gen    int // ignore un calcul dépassé par un plus récent
+}
+
+func newSuggestionsBar(onOpen func(models.Artist)) *suggestionsBar {
+	b := &suggestionsBar{title: widget.NewLabel(""), items: container.NewHBox(), onOpen: onOpen}
+	b.title.TextStyle = fyne.TextStyle{Bold: true}
+	b.box = container.NewBorder(nil, nil, b.title, nil, container.NewHScroll(b.items))
+	b.box.Hide()
+	return b
+}
+
+// update calcule les suggestions en arrière-plan ; concerts peut valoir nil
+func (b *suggestionsBar) update(artists []models.Artist, concerts map[int][]models.Concert) {
+	b.title.SetText(TR("suggest_title"))
+	b.gen++
+	gen := b.gen
+	go func() {
+		suggestions := stats.Recommend(artists, store.LoadFavorites(), concerts, suggestCount)
+		fyne.Do(func() {
+			if gen != b.gen {
+				return
+			}
+			b.items.Objects = nil
+			for _, s := range suggestions {
+				a := s.Artist
+				b.items.Add(widget.NewButton(fmt.Sprintf(TR("suggest_because"), a.Name, s.Because.Name), func() { b.onOpen(a) }))
+			}
+			b.items.Refresh()
+			if len(suggestions) == 0 {
+				b.box.Hide()
+			} else {
+				b.box.Show()
+			}
+		})
+	}()
+}
@@ -0,0 +1,369 @@
+package ui
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+	"time"
+
+	"groupie-tracker/api"
+	"groupie-tracker/models"
+	"groupie-tracker/stats"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// timelineZoom est l'échelle de l'axe des dates
+type timelineZoom int
+
+const (
+	zoomYear timelineZoom = iota
+	zoomMonth
+)
+
+// Dimensions de la frise (en points)
+const (
+	tlPadding     = 24
+	tlYearWidth   = 24 // largeur minimale d'une année en vue annuelle
+	tlMonthWidth  = 36 // largeur d'un mois en vue mensuelle
+	tlDot         = 10
+	tlLanes       = 4 // rangées de points avant de se superposer
+	tlMarkersTop  = 22
+	tlAxisY       = tlMarkersTop + tlLanes*(tlDot+4) + 8
+	tlHeight      = tlAxisY + 34 // libellés et barre de défilement
+	tlLabelSize   = 11
+	tlHoverRadius = tlDot
+)
+
+// Couleurs des pays, attribuées dans l'ordre des concerts
+var timelinePalette = []color.NRGBA{
+	{R: 0, G: 255, B: 255, A: 255},
+	{R: 255, G: 0, B: 128, A: 255},
+	{R: 255, G: 200, B: 0, A: 255},
+	{R: 120, G: 255, B: 120, A: 255},
+	{R: 170, G: 120, B: 255, A: 255},
+	{R: 255, G: 130, B: 60, A: 255},
+	{R: 90, G: 160, B: 255, A: 255},
+	{R: 255, G: 255, B: 255, A: 255},
+}
+
+// concertTimeline place les concerts d'un artiste sur un axe des dates, avec
+// des repères pour la création du groupe et le premier album. Le survol d'un
+// concert appelle onHover avec son lieu et sa date ("" en sortie).
+type concertTimeline struct {
+	widget.BaseWidget
+
+	concerts   []models.Concert
+	creation   time.Time
+	firstAlbum time.Time
+	zoom       timelineZoom
+	onHover    func(string)
+
+	countryColor map[string]color.Color
+	countries    []string // dans l'ordre d'attribution des couleurs
+	start, end   time.Time
+	points       []fyne.Position // centre de chaque concert, calculé au Layout
+	hover        int
+}
+
+func newConcertTimeline(artist models.Artist, concerts []models.Concert, onHover func(string)) *concertTimeline {
+	t := &concertTimeline{concerts: concerts, onHover: onHover, hover: -1, countryColor: make(map[string]color.Color)}
+	if artist.CreationDate > 0 {
+		t.creation = time.Date(artist.CreationDate, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	t.firstAlbum, _ = time.Parse("02-01-2006", artist.FirstAlbum)
+
+	for _, c := range concerts {
+		country := stats.Country(c.Location)
+		if _, ok := t.countryColor[country]; !ok {
+			t.countryColor[country] = timelinePalette[len(t.countries)%len(timelinePalette)]
+			t.countries = append(t.countries, country)
+		}
+	}
+
+	// L'axe couvre des années entières, de la plus ancienne date connue à la plus récente
+	var first, last time.Time
+	for _, d := range t.dates() {
+		if first.IsZero() || d.Before(first) {
+			first = d
+		}
+		if d.After(last) {
+			last = d
+		}
+	}
+	if !first.IsZero() {
+		t.start = time.Date(first.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+		t.end = time.Date(last.Year()+1, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	t.ExtendBaseWidget(t)
+	return t
+}
+
+// dates retourne toutes les dates connues (concerts et repères)
+func (t *concertTimeline) dates() []time.Time {
+	var out []time.Time
+	for _, c := range t.concerts {
+		out = append(out, c.Date)
+	}
+	for _, d := range []time.Time{t.creation, t.firstAlbum} {
+		if !d.IsZero() {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// SetZoom change l'échelle ; la frise s'élargit en vue mensuelle
+func (t *concertTimeline) SetZoom(z timelineZoom) {
+	t.zoom = z
+	t.Refresh()
+}
+
+// legend retourne les pays et leur couleur, dans l'ordre des concerts
+func (t *concertTimeline) legend() ([]string, map[string]color.Color) {
+	return t.countries, t.countryColor
+}
+
+func (t *concertTimeline) axisWidth() float32 {
+	if t.start.IsZero() {
+		return 0
+	}
+	years := t.end.Year() - t.start.Year()
+	if t.zoom == zoomMonth {
+		return float32(years*12) * tlMonthWidth
+	}
+	return float32(years) * tlYearWidth
+}
+
+func (t *concertTimeline) xOf(d time.Time, width float32) float32 {
+	span := t.end.Sub(t.start).Hours()
+	if span <= 0 {
+		return tlPadding
+	}
+	return tlPadding + float32(d.Sub(t.start).Hours()/span)*(width-2*tlPadding)
+}
+
+func (t *concertTimeline) MouseIn(e *desktop.MouseEvent) {
+	t.MouseMoved(e)
+}
+
+func (t *concertTimeline) MouseMoved(e *desktop.MouseEvent) {
+	t.setHover(t.hit(e.Position))
+}
+
+func (t *concertTimeline) MouseOut() {
+	t.setHover(-1)
+}
+
+// Tapped affiche aussi le concert touché, pour les écrans sans souris
+func (t *concertTimeline) Tapped(e *fyne.PointEvent) {
+	t.setHover(t.hit(e.Position))
+}
+
+// hit retourne le concert le plus proche de pos, ou -1
+func (t *concertTimeline) hit(pos fyne.Position) int {
+	best, bestDist := -1, float32(tlHoverRadius)
+	for i, p := range t.points {
+		dist := float32(math.Hypot(float64(p.X-pos.X), float64(p.Y-pos.Y)))
+		if dist <= bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
+
+func (t *concertTimeline) setHover(i int) {
+	if i == t.hover {
+		return
+	}
+	t.hover = i
+	t.Refresh()
+	if t.onHover == nil {
+		return
+	}
+	if i < 0 {
+		t.onHover("")
+		return
+	}
+	c := t.concerts[i]
+	t.onHover(c.Date.Format("02/01/2006") + " — " + api.LocationLabel(c.Location))
+}
+
+func (t *concertTimeline) CreateRenderer() fyne.WidgetRenderer {
+	return &timelineRenderer{t: t}
+}
+
+type timelineRenderer struct {
+	t       *concertTimeline
+	objects []fyne.CanvasObject
+}
+
+func (r *timelineRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(r.t.axisWidth()+2*tlPadding, tlHeight)
+}
+
+func (r *timelineRenderer) Layout(size fyne.Size) {
+	r.build(size)
+}
+
+func (r *timelineRenderer) Refresh() {
+	r.build(r.t.Size())
+	canvas.Refresh(r.t)
+}
+
+func (r *timelineRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *timelineRenderer) Destroy() {}
+
+func timelineText(text string, col color.Color, x, y float32) *canvas.Text {
+	txt := canvas.NewText(text, col)
+	txt.TextSize = tlLabelSize
+	txt.Move(fyne.NewPos(x, y))
+	return txt
+}
+
+// build recrée les objets : axe, graduations, repères puis concerts
+func (r *timelineRenderer) build(size fyne.Size) {
+	t := r.t
+	r.objects = r.objects[:0]
+	t.points = t.points[:0]
+	if t.start.IsZero() || size.Width <= 0 {
+		return
+	}
+
+	axis := canvas.NewLine(ColText)
+	axis.StrokeWidth = 1
+	axis.Position1 = fyne.NewPos(tlPadding, tlAxisY)
+	axis.Position2 = fyne.NewPos(size.Width-tlPadding, tlAxisY)
+	r.objects = append(r.objects, axis)
+
+	// Graduations : une par année, ou une par mois avec l'année en janvier
+	step := 1
+	if t.zoom == zoomYear {
+		// On espace les libellés quand les années sont trop serrées
+		perYear := (size.Width - 2*tlPadding) / float32(t.end.Year()-t.start.Year())
+		for perYear*float32(step) < 40 {
+			step++
+		}
+	}
+	for d := t.start; !d.After(t.end); {
+		x := t.xOf(d, size.Width)
+		major := d.Month() == time.January
+		tick := canvas.NewLine(ColText)
+		tick.Position1 = fyne.NewPos(x, tlAxisY)
+		tick.Position2 = fyne.NewPos(x, tlAxisY+4)
+		if major {
+			tick.Position2.Y += 3
+		}
+		r.objects = append(r.objects, tick)
+
+		switch {
+		case major && (t.zoom == zoomMonth || (d.Year()-t.start.Year())%step == 0):
+			r.objects = append(r.objects, timelineText(strconv.Itoa(d.Year()), ColText, x-12, tlAxisY+8))
+		case !major && t.zoom == zoomMonth:
+			r.objects = append(r.objects, timelineText(d.Format("01"), color.NRGBA{R: 150, G: 150, B: 170, A: 255}, x-6, tlAxisY+8))
+		}
+
+		if t.zoom == zoomMonth {
+			d = d.AddDate(0, 1, 0)
+		} else {
+			d = d.AddDate(1, 0, 0)
+		}
+	}
+
+	// Repères : création du groupe et premier album
+	marker := func(d time.Time, label string, col color.Color) {
+		if d.IsZero() {
+			return
+		}
+		x := t.xOf(d, size.Width)
+		line := canvas.NewLine(col)
+		line.StrokeWidth = 2
+		line.Position1 = fyne.NewPos(x, tlMarkersTop-6)
+		line.Position2 = fyne.NewPos(x, tlAxisY)
+		r.objects = append(r.objects, line, timelineText(label, col, x+3, 0))
+	}
+	marker(t.creation, TR("tl_creation"), ColHighlight)
+	marker(t.firstAlbum, TR("tl_album"), color.NRGBA{R: 255, G: 200, B: 0, A: 255})
+
+	// Concerts : un point par date, sur la première rangée libre pour ne pas se chevaucher
+	lanes := make([]float32, tlLanes)
+	for i := range lanes {
+		lanes[i] = -tlDot
+	}
+	for i, c := range t.concerts {
+		x := t.xOf(c.Date, size.Width)
+		lane := 0
+		for lane < tlLanes-1 && x-lanes[lane] < tlDot+2 {
+			lane++
+		}
+		lanes[lane] = x
+		y := float32(tlMarkersTop + lane*(tlDot+4) + tlDot/2)
+		t.points = append(t.points, fyne.NewPos(x, y))
+
+		dot := canvas.NewCircle(t.countryColor[stats.Country(c.Location)])
+		diameter := float32(tlDot)
+		if i == t.hover {
+			diameter += 6
+			dot.StrokeColor = ColText
+			dot.StrokeWidth = 2
+		}
+		dot.Resize(fyne.NewSize(diameter, diameter))
+		dot.Move(fyne.NewPos(x-diameter/2, y-diameter/2))
+		r.objects = append(r.objects, dot)
+	}
+}
+
+// concertTimelineCard assemble la frise, le choix de l'échelle, le concert
+// survolé et la légende des pays ; nil s'il n'y a aucun concert
+func concertTimelineCard(artist models.Artist, concerts []models.Concert) fyne.CanvasObject {
+	if len(concerts) == 0 {
+		return nil
+	}
+
+	hoverLbl := widget.NewLabel(TR("tl_hint"))
+	timeline := newConcertTimeline(artist, concerts, func(text string) {
+		if text == "" {
+			text = TR("tl_hint")
+		}
+		hoverLbl.SetText(text)
+	})
+
+	// La largeur minimale change avec l'échelle : le défilement doit être recalculé
+	scroll := container.NewHScroll(timeline)
+	zoomRadio := widget.NewRadioGroup([]string{TR("tl_year"), TR("tl_month")}, func(s string) {
+		if s == TR("tl_month") {
+			timeline.SetZoom(zoomMonth)
+		} else {
+			timeline.SetZoom(zoomYear)
+		}
+		scroll.Refresh()
+	})
+	zoomRadio.Horizontal = true
+	zoomRadio.Required = true
+	zoomRadio.SetSelected(TR("tl_year"))
+
+	legend := container.NewGridWrap(fyne.NewSize(150, 18))
+	countries, colors := timeline.legend()
+	for _, country := range countries {
+		legend.Add(timelineText("● "+country, colors[country], 0, 0))
+	}
+
+	title := canvas.NewText(TR("tl_title"), ColHighlight)
+	title.TextSize = 14
+	title.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
+
+	return container.NewMax(canvas.NewRectangle(ColCard), container.NewPadded(container.NewVBox(
+		container.NewBorder(nil, nil, title, zoomRadio),
+		scroll,
+		hoverLbl,
+		legend,
+	)))
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"sync/atomic"
+
+	"groupie-tracker/api"
+	"groupie-tracker/images"
+	"groupie-tracker/models"
+	"groupie-tracker/stats"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Carte du monde : tuiles OpenStreetMap au zoom 1, soit 2 x 2 tuiles de 256 px
+const (
+	worldMapZoom = 1
+	worldMapTile = 256
+	worldMapSize = worldMapTile << worldMapZoom
+	worldMapDot  = 8
+)
+
+// worldMap affiche le monde et des repères colorés posés par latitude/longitude
+type worldMap struct {
+	box     fyne.CanvasObject
+	markers *fyne.Container // sans layout : repères positionnés en pixels
+}
+
+func newWorldMap() *worldMap {
+	tiles := container.NewGridWrap(fyne.NewSize(worldMapTile, worldMapTile))
+	for y := 0; y < 1<<worldMapZoom; y++ {
+		for x := 0; x < 1<<worldMapZoom; x++ {
+			img := canvas.NewImageFromImage(nil)
+			img.FillMode = canvas.ImageFillStretch
+			tiles.Add(img)
+			// Les tuiles passent par le service d'images : cache disque et mémoire
+			go func(url string) {
+				tile, err := images.Thumbnail(url, worldMapTile)
+				if err != nil {
+					return
+				}
+				fyne.Do(func() {
+					img.Image = tile
+					img.Refresh()
+				})
+			}(api.OSMTileURL(worldMapZoom, x, y))
+		}
+	}
+
+	m := &worldMap{markers: container.NewWithoutLayout()}
+	m.box = container.NewGridWrap(fyne.NewSize(worldMapSize, worldMapSize),
+		container.NewStack(canvas.NewRectangle(color.NRGBA{R: 170, G: 211, B: 223, A: 255}), tiles, m.markers))
+	return m
+}
+
+// mercator projette une position sur la carte (projection Web Mercator)
+func mercator(lat, lon float64) (float32, float32) {
+	lat = max(min(lat, 85.05), -85.05)
+	x := (lon + 180) / 360 * worldMapSize
+	rad := lat * math.Pi / 180
+	y := (1 - math.Log(math.Tan(rad)+1/math.Cos(rad))/math.Pi) / 2 * worldMapSize
+	return float32(x), float32(y)
+}
+
+// addMarker pose un repère ; shift décale horizontalement les repères de
+// plusieurs séries tombant sur la même ville
+func (m *worldMap) addMarker(lat, lon float64, col color.Color, shift int) {
+	x, y := mercator(lat, lon)
+	dot := canvas.NewCircle(col)
+	dot.StrokeColor = color.Black
+	dot.StrokeWidth = 1
+	dot.Resize(fyne.NewSize(worldMapDot, worldMapDot))
+	dot.Move(fyne.NewPos(x-worldMapDot/2+float32(shift*worldMapDot/2), y-worldMapDot/2))
+	m.markers.Add(dot)
+}
+
+// MapScreen place toutes les villes de concert sur la carte du monde. Les
+// villes sont géocodées une à une (le service limite le débit) ; stop arrête
+// le géocodage quand l'écran sort de l'historique.
+func MapScreen(artists []models.Artist, onBack func()) (view fyne.CanvasObject, stop func()) {
+	var closed atomic.Bool
+
+	title := canvas.NewText(TR("map_title"), ColAccent)
+	title.TextSize = 20
+	title.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
+
+	world := newWorldMap()
+	status := widget.NewLabel(TR("geo_loading"))
+	status.Wrapping = fyne.TextWrapWord
+
+	go func() {
+		concerts, err := loadConcerts(artists)
+		if err != nil {
+			fyne.Do(func() { status.SetText(err.Error()) })
+			return
+		}
+		cities := stats.Cities(nil, concerts)
+		for n, c := range cities {
+			if closed.Load() {
+				return
+			}
+			latStr, lonStr, err := api.GetCoordinates(api.LocationLabel(c.Location))
+			lat, errLat := strconv.ParseFloat(latStr, 64)
+			lon, errLon := strconv.ParseFloat(lonStr, 64)
+			located := err == nil && errLat == nil && errLon == nil
+			fyne.Do(func() {
+				if located {
+					world.addMarker(lat, lon, ColHighlight, 0)
+				}
+				status.SetText(fmt.Sprintf(TR("geo_progress"), n+1, len(cities)))
+			})
+		}
+		fyne.Do(func() { status.SetText(fmt.Sprintf(TR("map_done"), len(cities))) })
+	}()
+
+	header := container.NewVBox(
+		container.NewBorder(nil, nil,
+			widget.NewButtonWithIcon(TR("back_btn"), theme.NavigateBackIcon(), onBack),
+			nil,
+			container.NewCenter(title),
+		),
+		widget.NewSeparator(),
+	)
+
+	body := container.NewVBox(container.NewCenter(world.box), status)
+	view = container.NewMax(canvas.NewRectangle(ColBackground), container.NewBorder(header, nil, nil, nil, container.NewScroll(container.NewPadded(body))))
+	return view, func() { closed.Store(true) }
+}
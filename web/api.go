@@ -0,0 +1,177 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"groupie-tracker/api"
+	"groupie-tracker/catalog"
+	"groupie-tracker/models"
+	"groupie-tracker/store"
+)
+
+// artistJSON est un artiste de l'API avec son favori et, pour la fiche, ses concerts
+type artistJSON struct {
+	models.Artist
+	Local    bool             `json:"local,omitempty"`
+	Favorite *models.Favorite `json:"favorite,omitempty"`
+	Concerts []models.Concert `json:"concerts,omitempty"`
+}
+
+type errorJSON struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorJSON{Error: err.Error()})
+}
+
+var errNotFound = errors.New("artiste introuvable")
+
+func (s *Server) apiArtists(w http.ResponseWriter, r *http.Request) {
+	crit, err := catalog.ParseQuery(r.URL.Query())
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	favs, _ := store.LoadFavoriteEntries()
+	artists := s.cat.Index().Apply(crit, favs)
+
+	out := make([]artistJSON, len(artists))
+	for i, a := range artists {
+		out[i] = artistJSON{Artist: a, Local: s.cat.IsLocal(a.ID)}
+		if fav, ok := favs[a.ID]; ok {
+			out[i].Favorite = &fav
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) apiArtist(w http.ResponseWriter, r *http.Request) {
+	artist, ok := s.findArtist(r)
+	if !ok {
+		writeJSONError(w, http.StatusNotFound, errNotFound)
+		return
+	}
+	concerts, err := s.cat.Concerts(artist.ID)
+	if err != nil {
+		writeJSONError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	out := artistJSON{Artist: artist, Local: s.cat.IsLocal(artist.ID), Concerts: concerts}
+	favs, _ := store.LoadFavoriteEntries()
+	if fav, ok := favs[artist.ID]; ok {
+		out.Favorite = &fav
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) apiConcerts(w http.ResponseWriter, r *http.Request) {
+	artist, ok := s.findArtist(r)
+	if !ok {
+		writeJSONError(w, http.StatusNotFound, errNotFound)
+		return
+	}
+	concerts, err := s.cat.Concerts(artist.ID)
+	if err != nil {
+		writeJSONError(w, http.StatusBadGateway, err)
+		return
+	}
+	if concerts == nil {
+		concerts = []models.Concert{}
+	}
+	writeJSON(w, http.StatusOK, concerts)
+}
+
+func (s *Server) apiFavorites(w http.ResponseWriter, r *http.Request) {
+	favs, err := store.LoadFavoriteEntries()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, store.SortedFavorites(favs))
+}
+
+// apiPutFavorite ajoute ou modifie un favori ; le corps est facultatif :
+// {"rating": 4, "tags": ["live"], "note": "..."}
+func (s *Server) apiPutFavorite(w http.ResponseWriter, r *http.Request) {
+	artist, ok := s.findArtist(r)
+	if !ok {
+		writeJSONError(w, http.StatusNotFound, errNotFound)
+		return
+	}
+
+	var body struct {
+		Rating *int     `json:"rating"`
+		Tags   []string `json:"tags"`
+		Note   *string  `json:"note"`
+	}
+	if r.ContentLength != 0 {
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&body); err != nil {
+			writeJSONError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	if body.Rating != nil && (*body.Rating < 0 || *body.Rating > 5) {
+		writeJSONError(w, http.StatusBadRequest, errors.New("rating doit être entre 0 et 5"))
+		return
+	}
+
+	fav, err := store.UpdateFavorite(artist.ID, func(f *models.Favorite) {
+		if body.Rating != nil {
+			f.Rating = *body.Rating
+		}
+		if body.Tags != nil {
+			f.Tags = store.SplitTags(strings.Join(body.Tags, ","))
+		}
+		if body.Note != nil {
+			f.Note = strings.TrimSpace(*body.Note)
+		}
+	})
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, fav)
+}
+
+func (s *Server) apiDeleteFavorite(w http.ResponseWriter, r *http.Request) {
+	artist, ok := s.findArtist(r)
+	if !ok {
+		writeJSONError(w, http.StatusNotFound, errNotFound)
+		return
+	}
+	if err := store.RemoveFavorite(artist.ID); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// apiGeocode géocode un lieu de concert pour la carte de la fiche artiste
+func (s *Server) apiGeocode(w http.ResponseWriter, r *http.Request) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if q == "" {
+		writeJSONError(w, http.StatusBadRequest, errors.New("paramètre q manquant"))
+		return
+	}
+	lat, lon, err := api.GetCoordinates(api.LocationLabel(q))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, api.GeoResult{Lat: lat, Lon: lon})
+}
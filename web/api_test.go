@@ -0,0 +1,102 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"groupie-tracker/catalog"
+	"groupie-tracker/models"
+)
+
+// fakeAPI remplace l'API distante : les requêtes sortantes (http.Get) sont
+// servies par mux sans passer par le réseau
+type fakeAPI struct{ mux *http.ServeMux }
+
+func (f fakeAPI) RoundTrip(r *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	f.mux.ServeHTTP(rec, r)
+	return rec.Result(), nil
+}
+
+// newTestServer prépare un serveur sur un catalogue de count artistes factices
+func newTestServer(t *testing.T, count int) *Server {
+	t.Helper()
+	t.Setenv("GROUPIE_TRACKER_HOME", t.TempDir())
+
+	artists := make([]models.Artist, count)
+	for i := range artists {
+		artists[i] = models.Artist{ID: i + 1, Name: fmt.Sprintf("Groupe %d", i+1), Members: []string{"A"}, CreationDate: 1990 + i}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/artists", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(artists)
+	})
+	mux.HandleFunc("GET /api/relation/{id}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id": %s, "datesLocations": {"paris-france": ["01-02-2020", "*03-02-2020"], "lyon-france": ["05-06-2021"]}}`, r.PathValue("id"))
+	})
+
+	saved := http.DefaultTransport
+	http.DefaultTransport = fakeAPI{mux}
+	t.Cleanup(func() { http.DefaultTransport = saved })
+
+	cat, err := catalog.Load(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewServer(cat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// Les fiches et les concerts remplissent le cache des relations de l'API :
+// des requêtes simultanées ne doivent pas se gêner (go test -race)
+func TestParallelRequests(t *testing.T) {
+	const artists = 8
+	s := newTestServer(t, artists)
+
+	tests := []struct {
+		method, path string
+		status       int
+	}{
+		{"GET", "/api/artists/%d", http.StatusOK},
+		{"GET", "/api/artists/%d/concerts", http.StatusOK},
+		{"GET", "/artists/%d", http.StatusOK},
+		{"PUT", "/api/favorites/%d", http.StatusOK},
+		{"GET", "/api/artists?q=groupe&min_creation=199%d", http.StatusOK},
+		{"GET", "/api/artists/%d0", http.StatusNotFound},
+	}
+
+	var wg sync.WaitGroup
+	for round := range 3 {
+		for id := 1; id <= artists; id++ {
+			for _, tt := range tests {
+				wg.Go(func() {
+					path := fmt.Sprintf(tt.path, id)
+					rec := httptest.NewRecorder()
+					s.ServeHTTP(rec, httptest.NewRequest(tt.method, path, nil))
+					if rec.Code != tt.status {
+						t.Errorf("tour %d : %s %s = %d, attendu %d (%s)", round, tt.method, path, rec.Code, tt.status, strings.TrimSpace(rec.Body.String()))
+					}
+				})
+			}
+		}
+	}
+	wg.Wait()
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("GET", "/api/artists/3/concerts", nil))
+	var concerts []models.Concert
+	if err := json.NewDecoder(rec.Body).Decode(&concerts); err != nil || len(concerts) != 3 {
+		t.Fatalf("concerts = %v, %v ; attendu 3 concerts", concerts, err)
+	}
+	if concerts[0].Location != "paris-france" || concerts[2].Location != "lyon-france" {
+		t.Errorf("concerts mal triés : %v", concerts)
+	}
+}
@@ -0,0 +1,156 @@
+package web
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"groupie-tracker/catalog"
+	"groupie-tracker/filter"
+	"groupie-tracker/models"
+	"groupie-tracker/store"
+)
+
+// artistCard est un artiste de la page d'accueil avec son éventuel favori
+type artistCard struct {
+	models.Artist
+	Favorite *models.Favorite
+}
+
+var sortLabels = []struct {
+	Key   filter.SortKey
+	Label string
+}{
+	{filter.SortNameAsc, "Nom (A-Z)"},
+	{filter.SortNameDesc, "Nom (Z-A)"},
+	{filter.SortCreationNew, "Année Création (Récent)"},
+	{filter.SortCreationOld, "Année Création (Ancien)"},
+	{filter.SortFirstAlbumNew, "Premier Album (Récent)"},
+	{filter.SortFirstAlbumOld, "Premier Album (Ancien)"},
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	crit, err := catalog.ParseQuery(q)
+	if err != nil {
+		s.renderError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	favs, _ := store.LoadFavoriteEntries()
+	artists := s.cat.Index().Apply(crit, favs)
+	cards := make([]artistCard, len(artists))
+	for i, a := range artists {
+		cards[i].Artist = a
+		if fav, ok := favs[a.ID]; ok {
+			cards[i].Favorite = &fav
+		}
+	}
+
+	members := make(map[int]bool)
+	for _, n := range crit.Members {
+		members[n] = true
+	}
+	collections, _ := store.LoadCollections()
+
+	s.render(w, http.StatusOK, "index", map[string]any{
+		"Title":       "Artistes",
+		"Query":       q,
+		"Criteria":    crit,
+		"Members":     members,
+		"MembersMax":  filter.MembersMax,
+		"Sorts":       sortLabels,
+		"Collections": collections,
+		"Artists":     cards,
+		// Adresse de la page, pour revenir à la même liste après un changement de favori
+		"Return": r.URL.RequestURI(),
+	})
+}
+
+func (s *Server) handleArtist(w http.ResponseWriter, r *http.Request) {
+	artist, ok := s.findArtist(r)
+	if !ok {
+		s.renderError(w, http.StatusNotFound, "Artiste introuvable.")
+		return
+	}
+
+	concerts, err := s.cat.Concerts(artist.ID)
+	concertsErr := ""
+	if err != nil {
+		concertsErr = err.Error()
+	}
+
+	// Lieux distincts, dans l'ordre de leur premier concert, pour la carte
+	var places []string
+	for _, c := range concerts {
+		if !slices.Contains(places, c.Location) {
+			places = append(places, c.Location)
+		}
+	}
+
+	favs, _ := store.LoadFavoriteEntries()
+	var favorite *models.Favorite
+	if fav, ok := favs[artist.ID]; ok {
+		favorite = &fav
+	}
+
+	s.render(w, http.StatusOK, "artist", map[string]any{
+		"Title":       artist.Name,
+		"Artist":      artist,
+		"Local":       s.cat.IsLocal(artist.ID),
+		"Favorite":    favorite,
+		"Concerts":    concerts,
+		"ConcertsErr": concertsErr,
+		"Places":      places,
+		"Saved":       r.URL.Query().Get("saved") != "",
+		"Return":      r.URL.Path,
+	})
+}
+
+// handleFavoriteForm traite les formulaires de favori (ajout, retrait, note) puis redirige
+func (s *Server) handleFavoriteForm(w http.ResponseWriter, r *http.Request) {
+	artist, ok := s.findArtist(r)
+	if !ok {
+		s.renderError(w, http.StatusNotFound, "Artiste introuvable.")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.renderError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var err error
+	switch r.PostForm.Get("action") {
+	case "add":
+		_, err = store.UpdateFavorite(artist.ID, func(*models.Favorite) {})
+	case "remove":
+		err = store.RemoveFavorite(artist.ID)
+	case "save":
+		rating, _ := strconv.Atoi(r.PostForm.Get("rating"))
+		_, err = store.UpdateFavorite(artist.ID, func(f *models.Favorite) {
+			f.Rating = rating
+			f.Tags = store.SplitTags(r.PostForm.Get("tags"))
+			f.Note = strings.TrimSpace(r.PostForm.Get("note"))
+		})
+	default:
+		s.renderError(w, http.StatusBadRequest, "Action inconnue.")
+		return
+	}
+	if err != nil {
+		s.renderError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, safeReturn(r.PostForm.Get("return"), artist.ID), http.StatusSeeOther)
+}
+
+// safeReturn n'accepte qu'une adresse locale pour la redirection
+func safeReturn(ret string, id int) string {
+	u, err := url.Parse(ret)
+	if err != nil || ret == "" || u.IsAbs() || u.Host != "" || !strings.HasPrefix(u.Path, "/") || strings.HasPrefix(ret, "//") {
+		return "/artists/" + strconv.Itoa(id)
+	}
+	return ret
+}
@@ -0,0 +1,143 @@
+// Package web est le mode serveur (groupie-tracker web) : pages HTML rendues
+// côté serveur et API JSON, pour utiliser l'application depuis un navigateur
+// sans la chaîne de compilation Fyne.
+package web
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"groupie-tracker/api"
+	"groupie-tracker/catalog"
+	"groupie-tracker/models"
+)
+
+//go:embed templates/*.html
+var templateFiles embed.FS
+
+//go:embed static
+var staticFiles embed.FS
+
+// DefaultAddr n'écoute que sur la machine locale
+const DefaultAddr = "127.0.0.1:8080"
+
+// Server sert les pages et l'API à partir du catalogue
+type Server struct {
+	cat     *catalog.Catalog
+	pages   map[string]*template.Template
+	handler http.Handler
+}
+
+var funcs = template.FuncMap{
+	"stars": func(n int) string {
+		return strings.Repeat("★", n) + strings.Repeat("☆", 5-n)
+	},
+	"location": api.LocationLabel,
+	"date": func(t time.Time) string {
+		return t.Format("02/01/2006")
+	},
+	"join": strings.Join,
+	"seq": func(from, to int) []int {
+		var s []int
+		for i := from; i <= to; i++ {
+			s = append(s, i)
+		}
+		return s
+	},
+}
+
+// NewServer prépare les gabarits et les routes
+func NewServer(cat *catalog.Catalog) (*Server, error) {
+	s := &Server{cat: cat, pages: make(map[string]*template.Template)}
+	for _, page := range []string{"index", "artist", "error"} {
+		t, err := template.New("layout.html").Funcs(funcs).ParseFS(templateFiles, "templates/layout.html", "templates/"+page+".html")
+		if err != nil {
+			return nil, err
+		}
+		s.pages[page] = t
+	}
+
+	static, err := fs.Sub(staticFiles, "static")
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+	mux.HandleFunc("GET /artists/{id}", s.handleArtist)
+	mux.HandleFunc("POST /artists/{id}/favorite", s.handleFavoriteForm)
+	mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServerFS(static)))
+
+	mux.HandleFunc("GET /api/artists", s.apiArtists)
+	mux.HandleFunc("GET /api/artists/{id}", s.apiArtist)
+	mux.HandleFunc("GET /api/artists/{id}/concerts", s.apiConcerts)
+	mux.HandleFunc("GET /api/favorites", s.apiFavorites)
+	mux.HandleFunc("PUT /api/favorites/{id}", s.apiPutFavorite)
+	mux.HandleFunc("DELETE /api/favorites/{id}", s.apiDeleteFavorite)
+	mux.HandleFunc("GET /api/geocode", s.apiGeocode)
+
+	// Les formulaires et l'API modifient les favoris : on refuse les requêtes
+	// envoyées par un autre site ouvert dans le navigateur
+	s.handler = http.NewCrossOriginProtection().Handler(mux)
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// ListenAndServe charge les données et sert l'application sur addr
+func ListenAndServe(addr string) error {
+	cat, err := catalog.Load(true)
+	if err != nil {
+		return err
+	}
+	s, err := NewServer(cat)
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	log.Printf("Groupie Tracker disponible sur http://%s", ln.Addr())
+
+	srv := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return srv.Serve(ln)
+}
+
+// findArtist lit l'identifiant {id} de l'URL
+func (s *Server) findArtist(r *http.Request) (models.Artist, bool) {
+	return s.cat.Find(r.PathValue("id"))
+}
+
+func (s *Server) render(w http.ResponseWriter, status int, page string, data any) {
+	var buf strings.Builder
+	if err := s.pages[page].Execute(&buf, data); err != nil {
+		log.Printf("web: gabarit %s : %v", page, err)
+		http.Error(w, "erreur interne", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	fmt.Fprint(w, buf.String())
+}
+
+func (s *Server) renderError(w http.ResponseWriter, status int, message string) {
+	s.render(w, status, "error", map[string]any{
+		"Title":   http.StatusText(status),
+		"Status":  status,
+		"Message": message,
+	})
+}
@@ -0,0 +1,31 @@
+// Carte des concerts de la fiche artiste : les lieux sont géocodés un par un
+// par le serveur (/api/geocode), qui respecte la limite de Nominatim.
+document.addEventListener("DOMContentLoaded", async () => {
+  const el = document.getElementById("map");
+  if (!el || typeof L === "undefined") {
+    return;
+  }
+  const places = el.dataset.places.split("|").filter(Boolean);
+  const map = L.map(el).setView([20, 0], 2);
+  L.tileLayer("https://tile.openstreetmap.org/{z}/{x}/{y}.png", {
+    maxZoom: 18,
+    attribution: "&copy; OpenStreetMap",
+  }).addTo(map);
+
+  const points = [];
+  for (const place of places) {
+    try {
+      const resp = await fetch("/api/geocode?q=" + encodeURIComponent(place));
+      if (!resp.ok) {
+        continue;
+      }
+      const geo = await resp.json();
+      const point = [parseFloat(geo.lat), parseFloat(geo.lon)];
+      points.push(point);
+      L.marker(point).addTo(map).bindPopup(place.replace(/_/g, " ").replace(/-/g, ", "));
+      map.fitBounds(points, { padding: [30, 30], maxZoom: 8 });
+    } catch (e) {
+      // lieu ignoré
+    }
+  }
+});